@@ -32,7 +32,14 @@ var adrCmd = &cobra.Command{
 	Long: `Create and List ADR's:
 
 rex adr create -t "My Title" -a "User Name"
-rex adr list`,
+rex adr revision 1 -m "What changed"
+rex adr status 1 Accepted
+rex adr supersede 1 --by 2
+rex adr reserve "My Title"
+rex adr renumber
+rex adr list
+rex adr show 1
+rex adr search "status:accepted cache"`,
 }
 
 func init() {
@@ -22,6 +22,11 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -31,8 +36,15 @@ import (
 )
 
 var (
-	title  string
-	author string
+	title       string
+	author      string
+	newStatus   string
+	tags        []string
+	deciders    []string
+	interactive bool
+	edit        bool
+	templ       string
+	sets        []string
 )
 
 // adrCreateCmd represents the adrCreate command
@@ -42,28 +54,42 @@ var adrCreateCmd = &cobra.Command{
 	Long: `Create a new ADR in the path specified in the .rex.yaml config. For example:
 
 rex create -t "My ADR Title" -a "Donald Gifford"
-`,
-	Run: func(cmd *cobra.Command, args []string) {
-		// create adr content
-		content := adr.Content{
-			Title:  title,
-			Author: author,
-			Status: "Draft",
-			Date:   time.Now().Format(time.DateOnly),
-		}
+rex create -t "Use Postgres" --status Proposed --tags database,storage
+rex create -t "Rotate API keys" --template security-review
+rex create -t "Move to gRPC" --set jira=PLAT-123 --set cost_impact=3
 
-		rex := rex.New()
-		// maybe return file name and location where it was created
-		err := rex.NewADR(&content)
-		if err != nil {
-			cmd.Println(err.Error())
-		}
+'--template' selects the template the ADR is written with. The built in
+templates are madr, nygard, y-statement, lightweight and security-review,
+more can be named under "templates.adr.named" in your .rex.yaml.
+
+'--set key=value' sets a field configured under "adr.fields" in your
+.rex.yaml, it can be repeated. Fields are written to the front matter of
+the ADR and can be used in templates as .Content.Fields.key, fields that
+aren't set use their default and required fields must have a value.
+
+A title is required. When run in a terminal without '--title' you are
+prompted for the title, author, status, tags, deciders and fields not set
+by flags, the author defaulting to the user.name in your git config. Pass
+'--interactive, -i' to be prompted even when a title is set.
+
+The path of the new ADR is printed. Pass '--edit, -e', or set
+"adr.edit_on_create: true" in your .rex.yaml, to open it in $VISUAL or
+$EDITOR. The ADR is checked once the editor exits.
+
+Passing '--force, -f' overwrites an existing ADR file with the same name.
 
-		// UpdateIndex always tries to update and regenerate the index
-		err = rex.UpdateIndex(true)
+If the ADR can't be created, or doesn't validate after being edited, the
+error is printed and rex exits with a non-zero status.
+`,
+	// errors are printed by RunE, the usage isn't shown for them
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := createADR(cmd)
 		if err != nil {
-			cmd.Println(err.Error())
+			cmd.PrintErrln(err.Error())
 		}
+		return err
 	},
 }
 
@@ -73,4 +99,207 @@ func init() {
 	adrCreateCmd.Flags().StringVarP(&title, "title", "t", "", "Title for ADR")
 	adrCreateCmd.Flags().
 		StringVarP(&author, "author", "a", "", "Author for ADR")
+	adrCreateCmd.Flags().
+		StringVarP(&newStatus, "status", "s", "", "Status for ADR, defaults to the initial status of the workflow")
+	adrCreateCmd.Flags().
+		StringSliceVar(&tags, "tags", nil, "Comma separated tags for ADR")
+	adrCreateCmd.Flags().
+		StringSliceVar(&deciders, "deciders", nil, "Comma separated deciders for ADR")
+	adrCreateCmd.Flags().
+		StringVar(&templ, "template", "", "Name of the template to create the ADR with")
+	adrCreateCmd.Flags().
+		StringArrayVar(&sets, "set", nil, "Set a field configured under adr.fields as key=value, can be repeated")
+	adrCreateCmd.Flags().
+		BoolVarP(&interactive, "interactive", "i", false, "prompt for values not set by flags")
+	adrCreateCmd.Flags().
+		BoolVarP(&edit, "edit", "e", false, "open the new ADR in $VISUAL or $EDITOR")
+	adrCreateCmd.Flags().
+		BoolVarP(&force, "force", "f", false, "overwrite an existing ADR file")
+}
+
+// createADR creates the ADR from the flags, prompting for values when
+// run interactively. Returns an error if the ADR can't be created, or if
+// it doesn't validate after being edited.
+func createADR(cmd *cobra.Command) error {
+	// create adr content, status is set to the initial
+	// status of the configured workflow if not given
+	fields, err := setFields(sets)
+	if err != nil {
+		return err
+	}
+
+	content := adr.Content{
+		Title:    title,
+		Author:   author,
+		Status:   newStatus,
+		Tags:     tags,
+		Deciders: deciders,
+		Template: templ,
+		Fields:   fields,
+		Date:     time.Now().Format(time.DateOnly),
+	}
+
+	if interactive || (title == "" && isTerminal(cmd.InOrStdin())) {
+		p := newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+		err := promptContent(p, &content)
+		if err != nil {
+			return err
+		}
+	}
+
+	if strings.TrimSpace(content.Title) == "" {
+		return errors.New("a title is required, pass --title or run in a terminal to be prompted for one")
+	}
+
+	rex := rex.New()
+	file, err := rex.NewADR(&content, force)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("created %s\n", file)
+
+	if !cmd.Flags().Changed("edit") {
+		edit = rex.Settings().ADR.EditOnCreate
+	}
+
+	var editErr error
+	if edit {
+		editErr = openEditor(cmd, file)
+		if editErr == nil {
+			editErr = rex.ValidateADR(file)
+		}
+	}
+
+	// UpdateIndex always tries to update and regenerate the index
+	err = rex.UpdateIndex(false)
+	if err != nil {
+		cmd.Println(err.Error())
+	}
+
+	return editErr
+}
+
+// promptContent asks for the values of content that are not set. The
+// author defaults to the git user.name and the status to the initial status
+// of the workflow.
+func promptContent(p *prompter, content *adr.Content) error {
+	workflow, err := adr.NewWorkflow()
+	if err != nil {
+		return err
+	}
+
+	content.Title, err = p.askRequired("Title", content.Title)
+	if err != nil {
+		return err
+	}
+
+	def := content.Author
+	if def == "" {
+		def = gitUserName()
+	}
+	content.Author, err = p.ask("Author", def)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	status := workflow.Initial
+	if content.Status != "" {
+		status = cmp.Or(workflow.Status(content.Status), content.Status)
+	}
+	for {
+		status, err = p.ask(
+			fmt.Sprintf("Status (%s)", strings.Join(workflow.Statuses(), ", ")),
+			status,
+		)
+		if s := workflow.Status(status); s != "" {
+			content.Status = s
+			break
+		}
+
+		unknown := fmt.Sprintf("unknown status %q", status)
+		if errors.Is(err, io.EOF) {
+			return errors.New(unknown)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(p.out, unknown)
+		status = workflow.Initial
+	}
+
+	content.Tags, err = p.askList("Tags", content.Tags)
+	if err != nil {
+		return err
+	}
+
+	content.Deciders, err = p.askList("Deciders", content.Deciders)
+	if err != nil {
+		return err
+	}
+
+	return promptFields(p, content)
+}
+
+// promptFields asks for the values of the fields that apply to the template
+// of content and are not set, re-asking until the value has the type of the
+// field.
+func promptFields(p *prompter, content *adr.Content) error {
+	fields, err := adr.NewFields()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range adr.FieldsFor(fields, content.Template) {
+		if _, ok := content.Fields[f.Name]; ok {
+			continue
+		}
+
+		var value string
+		for {
+			if f.Required {
+				value, err = p.askRequired(f.Label(), f.Default)
+			} else {
+				value, err = p.ask(f.Label(), f.Default)
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+
+			_, perr := f.Parse(value)
+			if value == "" || perr == nil {
+				break
+			}
+			if err != nil {
+				return perr
+			}
+			fmt.Fprintln(p.out, perr.Error())
+		}
+
+		if value != "" {
+			if content.Fields == nil {
+				content.Fields = map[string]any{}
+			}
+			content.Fields[f.Name] = value
+		}
+	}
+
+	return nil
+}
+
+// setFields parses the key=value pairs passed with --set.
+func setFields(values []string) (map[string]any, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	fields := map[string]any{}
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set %q, must be key=value", v)
+		}
+		fields[key] = value
+	}
+	return fields, nil
 }
@@ -24,9 +24,13 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,9 +48,9 @@ func TestAdrCreateCMD(t *testing.T) {
 		err     bool
 	}{
 		"adr": {
-			file: "tests/docs/adr/3-Test-ADR-Create.md",
+			file: "tests/docs/adr/3-test-adr-create.md",
 			content: parseContentWithDate(
-				"# Test ADR Create\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | TESTER | %s | N/A | v0.0.1 |\n\n## Context and Problem Statement\n\n## Decision Drivers\n\n## Considered Options\n\n## Decision Outcome\n",
+				"---\nid: 3\ntitle: Test ADR Create\nstatus: Draft\nauthors:\n  - TESTER\ndate: \"%[1]s\"\nversion: v0.0.1\n---\n\n# Test ADR Create\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | TESTER | %[1]s | N/A | v0.0.1 |\n\n## Context and Problem Statement\n\n## Decision Drivers\n\n## Considered Options\n\n## Decision Outcome\n",
 			),
 			setArgs: []string{
 				"--config=tests/.rex.yaml",
@@ -94,3 +98,370 @@ func TestAdrCreateCMD(t *testing.T) {
 	// 	os.Exit(1)
 	// }
 }
+
+// resetCreateFlags clears the create flags set by earlier executions of
+// rootCmd.
+func resetCreateFlags() {
+	title, author, newStatus, templ = "", "", "", ""
+	tags, deciders, sets = nil, nil, nil
+	interactive, force, edit = false, false, false
+	adrCreateCmd.Flags().Lookup("edit").Changed = false
+}
+
+func TestAdrCreateCMDPrompts(t *testing.T) {
+	createPath := "tests/create/docs/adr/"
+	err := createTestFolder(createPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitUser := gitUserName
+	gitUserName = func() string { return "Git User" }
+	defer func() { gitUserName = gitUser }()
+
+	tests := []struct {
+		name    string
+		input   string
+		editor  string
+		output  string
+		file    string
+		content string
+		setArgs []string
+		err     bool
+	}{
+		{
+			name:   "no_title",
+			output: "a title is required, pass --title or run in a terminal to be prompted for one\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+			},
+			err: true,
+		},
+		{
+			name:   "prompt_title_required",
+			input:  "\n",
+			output: "Title: title is required\nTitle: \ntitle is required\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"-i",
+			},
+			err: true,
+		},
+		{
+			name:   "prompt",
+			input:  "Prompted ADR\n\nDone\nproposed\ndb, api\n\n",
+			output: "Title: Author [Git User]: Status (Draft, Proposed, Rejected, Accepted, Deprecated, Superseded) [Draft]: unknown status \"Done\"\nStatus (Draft, Proposed, Rejected, Accepted, Deprecated, Superseded) [Draft]: Tags (comma separated): Deciders (comma separated): created tests/create/docs/adr/1-prompted-adr.md\n",
+			file:   createPath + "1-prompted-adr.md",
+			content: parseContentWithDate(
+				"---\nid: 1\ntitle: Prompted ADR\nstatus: Proposed\nauthors:\n  - Git User\ndate: \"%[1]s\"\ntags:\n  - db\n  - api\nversion: v0.0.1\n---\n",
+			),
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"-i",
+			},
+		},
+		{
+			name:   "prompt_keeps_flags",
+			input:  "\n\n\n\nAlice,Bob\n",
+			output: "Title [Flagged]: Author [TESTER]: Status (Draft, Proposed, Rejected, Accepted, Deprecated, Superseded) [Accepted]: Tags (comma separated) [db]: Deciders (comma separated): created tests/create/docs/adr/2-flagged.md\n",
+			file:   createPath + "2-flagged.md",
+			content: parseContentWithDate(
+				"---\nid: 2\ntitle: Flagged\nstatus: Accepted\nauthors:\n  - TESTER\ndeciders:\n  - Alice\n  - Bob\ndate: \"%[1]s\"\ntags:\n  - db\nversion: v0.0.1\n---\n",
+			),
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"-i",
+				"--title=Flagged",
+				"--author=TESTER",
+				"--status=accepted",
+				"--tags=db",
+			},
+		},
+		{
+			name:   "prompt_unknown_status_flag",
+			output: "Title [Unknown]: \nAuthor [Git User]: \nStatus (Draft, Proposed, Rejected, Accepted, Deprecated, Superseded) [Done]: \nunknown status \"Done\"\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"-i",
+				"--title=Unknown",
+				"--status=Done",
+			},
+			err: true,
+		},
+		{
+			name:    "edit",
+			editor:  "sed -i s/Draft/Done/",
+			output:  "created tests/create/docs/adr/3-edited.md\ntests/create/docs/adr/3-edited.md: unknown status \"Done\", must be one of: Draft, Proposed, Rejected, Accepted, Deprecated, Superseded\n",
+			file:    createPath + "3-edited.md",
+			content: "---\nid: 3\ntitle: Edited\nstatus: Done\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"--title=Edited",
+				"--edit",
+			},
+			err: true,
+		},
+		{
+			name:   "edit_valid",
+			editor: "true",
+			output: "created tests/create/docs/adr/4-valid.md\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"--title=Valid",
+				"-e",
+			},
+		},
+		{
+			name:   "edit_no_editor",
+			output: "created tests/create/docs/adr/5-no-editor.md\nno editor set, set $VISUAL or $EDITOR to edit ADRs\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"--title=No Editor",
+				"-e",
+			},
+			err: true,
+		},
+		{
+			name:    "template",
+			output:  "created tests/create/docs/adr/6-lightweight.md\n",
+			file:    createPath + "6-lightweight.md",
+			content: "---\nid: 6\ntitle: Lightweight\nstatus: Draft\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"--title=Lightweight",
+				"--template=lightweight",
+			},
+		},
+		{
+			name:   "unknown_template",
+			output: "unknown ADR template \"missing\", must be one of: default, lightweight, madr, nygard, security-review, y-statement\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"--title=Missing",
+				"--template=missing",
+			},
+			err: true,
+		},
+		{
+			name:   "unknown_status_flag",
+			output: "unknown status \"Done\", must be one of: Draft, Proposed, Rejected, Accepted, Deprecated, Superseded\n",
+			setArgs: []string{
+				"--config=tests/.create-rex.yaml",
+				"adr",
+				"create",
+				"--title=Unknown",
+				"--status=Done",
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetCreateFlags()
+			t.Setenv("VISUAL", test.editor)
+			t.Setenv("EDITOR", "")
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			defer rootCmd.SetErr(nil)
+			rootCmd.SetIn(strings.NewReader(test.input))
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			if test.err {
+				assert.Error(t, err, "")
+			} else {
+				assert.Nil(t, err, "")
+			}
+			assert.Equal(t, test.output, buf.String(), "")
+
+			if test.file != "" {
+				b, err := os.ReadFile(test.file)
+				assert.Nil(t, err, "")
+				assert.True(t, strings.HasPrefix(string(b), test.content), string(b))
+			}
+		})
+	}
+
+	files, err := os.ReadDir(createPath)
+	assert.Nil(t, err, "")
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.Equal(
+		t,
+		[]string{"1-prompted-adr.md", "2-flagged.md", "3-edited.md", "4-valid.md", "5-no-editor.md", "6-lightweight.md", "README.md"},
+		names,
+		"",
+	)
+}
+
+func TestAdrCreateCMDFields(t *testing.T) {
+	fieldsPath := "tests/fields/docs/adr/"
+	err := createTestFolder(fieldsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("adr.fields", []any{
+		map[string]any{"name": "jira", "required": true, "prompt": "Jira ticket"},
+		map[string]any{"name": "cost_impact", "type": "int", "default": "0"},
+		map[string]any{"name": "threat_model", "templates": []any{"security-review"}},
+	})
+	defer viper.Set("adr.fields", nil)
+
+	tests := []struct {
+		name    string
+		input   string
+		output  string
+		file    string
+		content string
+		setArgs []string
+		err     bool
+	}{
+		{
+			name:    "set",
+			output:  "created tests/fields/docs/adr/1-set-fields.md\n",
+			file:    fieldsPath + "1-set-fields.md",
+			content: "---\nid: 1\ntitle: Set Fields\nstatus: Draft\ndate: \"%[1]s\"\nversion: v0.0.1\ncost_impact: 3\njira: PLAT-1\n---\n",
+			setArgs: []string{
+				"--config=tests/.fields-rex.yaml",
+				"adr",
+				"create",
+				"--title=Set Fields",
+				"--set=jira=PLAT-1",
+				"--set", "cost_impact=3",
+			},
+		},
+		{
+			name:   "required",
+			output: "field \"jira\" is required\n",
+			setArgs: []string{
+				"--config=tests/.fields-rex.yaml",
+				"adr",
+				"create",
+				"--title=Missing Jira",
+			},
+			err: true,
+		},
+		{
+			name:   "unknown",
+			output: "unknown field \"threat_model\", must be one of: jira, cost_impact\n",
+			setArgs: []string{
+				"--config=tests/.fields-rex.yaml",
+				"adr",
+				"create",
+				"--title=Unknown Field",
+				"--set=jira=PLAT-1",
+				"--set=threat_model=STRIDE",
+			},
+			err: true,
+		},
+		{
+			name:   "invalid_set",
+			output: "invalid --set \"jira\", must be key=value\n",
+			setArgs: []string{
+				"--config=tests/.fields-rex.yaml",
+				"adr",
+				"create",
+				"--title=Invalid",
+				"--set=jira",
+			},
+			err: true,
+		},
+		{
+			name:    "prompt",
+			input:   "Prompted Fields\n\n\n\n\n\nPLAT-2\nhigh\n5\n",
+			output:  "Title: Author: Status (Draft, Proposed, Rejected, Accepted, Deprecated, Superseded) [Draft]: Tags (comma separated): Deciders (comma separated): Jira ticket: jira ticket is required\nJira ticket: cost_impact [0]: cost_impact must be a number, got \"high\"\ncost_impact [0]: created tests/fields/docs/adr/2-prompted-fields.md\n",
+			file:    fieldsPath + "2-prompted-fields.md",
+			content: "---\nid: 2\ntitle: Prompted Fields\nstatus: Draft\ndate: \"%[1]s\"\nversion: v0.0.1\ncost_impact: 5\njira: PLAT-2\n---\n",
+			setArgs: []string{
+				"--config=tests/.fields-rex.yaml",
+				"adr",
+				"create",
+				"-i",
+			},
+		},
+	}
+
+	gitUser := gitUserName
+	gitUserName = func() string { return "" }
+	defer func() { gitUserName = gitUser }()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetCreateFlags()
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			defer rootCmd.SetErr(nil)
+			rootCmd.SetIn(strings.NewReader(test.input))
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			if test.err {
+				assert.Error(t, err, "")
+			} else {
+				assert.Nil(t, err, "")
+			}
+			assert.Equal(t, test.output, buf.String(), "")
+
+			if test.file != "" {
+				b, err := os.ReadFile(test.file)
+				assert.Nil(t, err, "")
+				content := parseContentWithDate(test.content)
+				assert.True(t, strings.HasPrefix(string(b), content), string(b))
+			}
+		})
+	}
+}
+
+func TestAdrCreateCMDExitStatus(t *testing.T) {
+	// run rex in a child process so the exit status can be checked
+	if os.Getenv("REX_TEST_EXECUTE") == "1" {
+		os.Args = []string{"rex", "--config=tests/.create-rex.yaml", "adr", "create"}
+		Execute()
+		return
+	}
+
+	// the child sets up its own test files, away from the ones in use here
+	cmd := exec.Command(os.Args[0], "-test.run=^TestAdrCreateCMDExitStatus$")
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(), "REX_TEST_EXECUTE=1")
+
+	// like a CI job, stdin is /dev/null so no title is prompted for
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	cmd.Stdin = devNull
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	assert.ErrorAs(t, err, &exitErr, "")
+	assert.Equal(t, 1, exitErr.ExitCode(), "")
+	assert.Equal(t, "a title is required, pass --title or run in a terminal to be prompted for one\n", stderr.String(), "")
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+var (
+	output       string
+	statusFilter string
+	authorFilter string
+)
+
+// adrRecord is the data output for each ADR by the list command
+type adrRecord struct {
+	ID      int    `json:"id"      yaml:"id"`
+	Title   string `json:"title"   yaml:"title"`
+	Status  string `json:"status"  yaml:"status"`
+	Author  string `json:"author"  yaml:"author"`
+	Created string `json:"created" yaml:"created"`
+	Updated string `json:"updated" yaml:"updated"`
+	File    string `json:"file"    yaml:"file"`
+}
+
+// adrListCmd represents the adrList command
+var adrListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the ADRs in the ADR path",
+	Long: `List the ADRs found in the path specified in the .rex.yaml config.
+Output defaults to a table and can be set to json, yaml, or csv with
+'--output, -o'. ADRs that can't be parsed are left out with a warning.
+For example:
+
+rex adr list
+rex adr list --status Accepted --author "Donald Gifford" -o json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rex := rex.New()
+		adrs, err := rex.ListADRs(statusFilter, authorFilter)
+		err = warnSkipped(cmd, err)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		err = writeADRs(cmd.OutOrStdout(), adrs, output)
+		if err != nil {
+			cmd.Println(err.Error())
+		}
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrListCmd)
+
+	adrListCmd.Flags().
+		StringVarP(&output, "output", "o", "table", "Output format: table, json, yaml, or csv")
+	adrListCmd.Flags().
+		StringVarP(&statusFilter, "status", "s", "", "Only list ADRs with this status")
+	adrListCmd.Flags().
+		StringVarP(&authorFilter, "author", "a", "", "Only list ADRs by this author")
+}
+
+// warnSkipped writes a warning for each ADR file in err that couldn't be
+// parsed and returns nil, any other error is returned.
+func warnSkipped(cmd *cobra.Command, err error) error {
+	var skipped *adr.SkippedError
+	if !errors.As(err, &skipped) {
+		return err
+	}
+
+	for _, e := range skipped.Errs {
+		cmd.PrintErrf("Warning: skipping %s: %v\n", e.File, e.Err)
+	}
+	return nil
+}
+
+// newADRRecords converts ADRs into the records used for output
+func newADRRecords(adrs []*adr.ADR) []adrRecord {
+	records := make([]adrRecord, 0, len(adrs))
+	for _, a := range adrs {
+		records = append(records, adrRecord{
+			ID:      a.ID,
+			Title:   a.Content.Title,
+			Status:  a.Content.Status,
+			Author:  a.Content.Author,
+			Created: a.Content.Date,
+			Updated: a.Content.Updated,
+			File:    a.File,
+		})
+	}
+	return records
+}
+
+// writeADRs writes the ADRs to w in the given output format
+func writeADRs(w io.Writer, adrs []*adr.ADR, format string) error {
+	records := newADRRecords(adrs)
+
+	switch format {
+	case "table":
+		return writeTable(w, records)
+	case "json":
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(records)
+	case "yaml":
+		e := yaml.NewEncoder(w)
+		e.SetIndent(2)
+		return e.Encode(records)
+	case "csv":
+		return writeCSV(w, records)
+	default:
+		return fmt.Errorf(
+			"unknown output %q, must be one of: table, json, yaml, csv",
+			format,
+		)
+	}
+}
+
+// writeTable writes the records as aligned columns
+func writeTable(w io.Writer, records []adrRecord) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tSTATUS\tAUTHOR\tCREATED\tUPDATED")
+	for _, r := range records {
+		fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\t%s\n",
+			r.ID,
+			r.Title,
+			r.Status,
+			r.Author,
+			r.Created,
+			r.Updated,
+		)
+	}
+	return tw.Flush()
+}
+
+// writeCSV writes the records as csv with a header row
+func writeCSV(w io.Writer, records []adrRecord) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write(
+		[]string{"id", "title", "status", "author", "created", "updated", "file"},
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		err = cw.Write([]string{
+			strconv.Itoa(r.ID),
+			r.Title,
+			r.Status,
+			r.Author,
+			r.Created,
+			r.Updated,
+			r.File,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
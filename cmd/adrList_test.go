@@ -0,0 +1,132 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrListCMD(t *testing.T) {
+	listPath := "tests/list/docs/adr/"
+	err := createTestFolder(listPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"1-First.md":  "# First\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Accepted | Alice | 2025-01-05 | 2025-01-06 | v0.0.2 |\n",
+		"2-Second.md": "# Second\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Draft | Bob | 2025-01-07 | N/A | v0.0.1 |\n",
+		"3-Broken.md": "---\nid: [\n---\n\n# Broken\n",
+		"README.md":   "# ADR Index\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(listPath+name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		output  string
+		setArgs []string
+	}{
+		"table": {
+			output: "ID  TITLE   STATUS    AUTHOR  CREATED     UPDATED\n1   First   Accepted  Alice   2025-01-05  2025-01-06\n2   Second  Draft     Bob     2025-01-07  \n",
+			setArgs: []string{
+				"--config=tests/.list-rex.yaml",
+				"adr",
+				"list",
+				"--output=table",
+				"--status=",
+				"--author=",
+			},
+		},
+		"json_status": {
+			output: "[\n  {\n    \"id\": 2,\n    \"title\": \"Second\",\n    \"status\": \"Draft\",\n    \"author\": \"Bob\",\n    \"created\": \"2025-01-07\",\n    \"updated\": \"\",\n    \"file\": \"tests/list/docs/adr/2-Second.md\"\n  }\n]\n",
+			setArgs: []string{
+				"--config=tests/.list-rex.yaml",
+				"adr",
+				"list",
+				"--output=json",
+				"--status=draft",
+				"--author=",
+			},
+		},
+		"yaml_author": {
+			output: "- id: 1\n  title: First\n  status: Accepted\n  author: Alice\n  created: \"2025-01-05\"\n  updated: \"2025-01-06\"\n  file: tests/list/docs/adr/1-First.md\n",
+			setArgs: []string{
+				"--config=tests/.list-rex.yaml",
+				"adr",
+				"list",
+				"--output=yaml",
+				"--status=",
+				"--author=ali",
+			},
+		},
+		"csv": {
+			output: "id,title,status,author,created,updated,file\n1,First,Accepted,Alice,2025-01-05,2025-01-06,tests/list/docs/adr/1-First.md\n2,Second,Draft,Bob,2025-01-07,,tests/list/docs/adr/2-Second.md\n",
+			setArgs: []string{
+				"--config=tests/.list-rex.yaml",
+				"adr",
+				"list",
+				"--output=csv",
+				"--status=",
+				"--author=",
+			},
+		},
+		"unknown_output": {
+			output: "unknown output \"xml\", must be one of: table, json, yaml, csv\n",
+			setArgs: []string{
+				"--config=tests/.list-rex.yaml",
+				"adr",
+				"list",
+				"--output=xml",
+				"--status=",
+				"--author=",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			errBuf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(errBuf)
+			defer rootCmd.SetErr(nil)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+
+			// the ADR that can't be parsed is skipped with a warning
+			assert.True(t, strings.HasPrefix(
+				errBuf.String(),
+				"Warning: skipping tests/list/docs/adr/3-Broken.md: invalid front matter: ",
+			), errBuf.String())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+// adrRelateCmd represents the adrRelate command
+var adrRelateCmd = &cobra.Command{
+	Use:     "relate <id> --to <id>",
+	Aliases: []string{"relates-to"},
+	Short:   "Mark two ADRs as related",
+	Long: `Record that two ADRs are related. Both ADRs link to each other under
+"Related ADRs" and the index is regenerated. For example:
+
+rex adr relate 3 --to 7`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			cmd.Printf("invalid ADR id: %s\n", args[0])
+			return
+		}
+
+		rex := rex.New()
+		err = rex.Relate(id, adr.RelatesTo, relatedID)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		cmd.Printf("ADR %d relates to ADR %d\n", id, relatedID)
+
+		// UpdateIndex always tries to update and regenerate the index
+		err = rex.UpdateIndex(false)
+		if err != nil {
+			cmd.Println(err.Error())
+		}
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrRelateCmd)
+
+	adrRelateCmd.Flags().
+		IntVarP(&relatedID, "to", "t", 0, "id of the related ADR")
+	_ = adrRelateCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createRelateADRs writes the ADRs used by the relationship commands
+func createRelateADRs(path string) error {
+	err := createTestFolder(path)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		"1-Old.md": "# Old\n\n| Status | Author |\n| ------ | ------ |\n| Accepted | TESTER |\n",
+		"2-New.md": "# New\n\n| Status | Author |\n| ------ | ------ |\n| Draft | TESTER |\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(path+name, []byte(content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAdrRelateCMD(t *testing.T) {
+	relatePath := "tests/relate/docs/adr/"
+	err := createRelateADRs(relatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "relate",
+			output: "ADR 1 relates to ADR 2\n",
+			setArgs: []string{
+				"--config=tests/.relate-rex.yaml",
+				"adr",
+				"relate",
+				"1",
+				"--to=2",
+			},
+		},
+		{
+			name:   "amend",
+			output: "ADR 1 is amended by ADR 2\n",
+			setArgs: []string{
+				"--config=tests/.relate-rex.yaml",
+				"adr",
+				"amends",
+				"1",
+				"--by=2",
+			},
+		},
+		{
+			name:   "missing",
+			output: "no ADR found with id 3 in tests/relate/docs/adr/\n",
+			setArgs: []string{
+				"--config=tests/.relate-rex.yaml",
+				"adr",
+				"relate",
+				"1",
+				"--to=3",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+
+	b, err := ReadTestFile(relatePath + "1-Old.md")
+	assert.Nil(t, err, "")
+	assert.Equal(
+		t,
+		"---\nid: 1\ntitle: Old\nstatus: Accepted\nauthors:\n  - TESTER\namended_by:\n  - 2\nrelates_to:\n  - 2\n---\n\n# Old\n\n| Status | Author |\n| ------ | ------ |\n| Accepted | TESTER |\n\n## Related ADRs\n\n- Relates to [ADR 2: New](2-New.md)\n- Amended by [ADR 2: New](2-New.md)\n",
+		string(b),
+		"",
+	)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+var dryRun bool
+
+// adrRenumberCmd represents the adrRenumber command
+var adrRenumberCmd = &cobra.Command{
+	Use:   "renumber",
+	Short: "Give ADRs sharing an id a new id",
+	Long: `Find ADRs sharing an id, usually created on parallel branches, and give
+all but the first a new id. The ADR with the earliest date keeps its id. If
+the dates match, the ADR most other ADRs link to keeps its id, then the file
+modified longest ago, then the first file by name.
+
+Renumbered ADRs are renamed, relations linking to them are updated and the
+index is regenerated. For example:
+
+rex adr renumber --dry-run
+rex adr renumber`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rex := rex.New()
+		moves, err := rex.Renumber(dryRun)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		if len(moves) == 0 {
+			cmd.Println("no duplicate ADR ids found")
+			return
+		}
+
+		for _, m := range moves {
+			cmd.Printf("%s: %s -> %s\n", m.Title, m.File, m.NewFile)
+		}
+
+		if dryRun {
+			return
+		}
+
+		// UpdateIndex always tries to update and regenerate the index
+		err = rex.UpdateIndex(false)
+		if err != nil {
+			cmd.Println(err.Error())
+		}
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrRenumberCmd)
+
+	adrRenumberCmd.Flags().
+		BoolVar(&dryRun, "dry-run", false, "print the changes without making them")
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrRenumberCMD(t *testing.T) {
+	renumberPath := "tests/renumber/docs/adr/"
+	err := createTestFolder(renumberPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"1-Old.md":   "---\nid: 1\ntitle: Old\nstatus: Accepted\ndate: \"2025-01-01\"\n---\n\n# Old\n",
+		"1-Other.md": "---\nid: 1\ntitle: Other\nstatus: Draft\ndate: \"2025-02-01\"\n---\n\n# Other\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(renumberPath+name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "dry_run",
+			output: "Other: 1-Other.md -> 2-Other.md\n",
+			setArgs: []string{
+				"--config=tests/.renumber-rex.yaml",
+				"adr",
+				"renumber",
+				"--dry-run",
+			},
+		},
+		{
+			name:   "renumber",
+			output: "Other: 1-Other.md -> 2-Other.md\n",
+			setArgs: []string{
+				"--config=tests/.renumber-rex.yaml",
+				"adr",
+				"renumber",
+				"--dry-run=false",
+			},
+		},
+		{
+			name:   "no_duplicates",
+			output: "no duplicate ADR ids found\n",
+			setArgs: []string{
+				"--config=tests/.renumber-rex.yaml",
+				"adr",
+				"renumber",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+
+	b, err := ReadTestFile(renumberPath + "2-Other.md")
+	assert.Nil(t, err, "")
+	assert.Equal(
+		t,
+		"---\nid: 2\ntitle: Other\nstatus: Draft\ndate: \"2025-02-01\"\n---\n\n# Other\n",
+		string(b),
+		"",
+	)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+// adrReserveCmd represents the adrReserve command
+var adrReserveCmd = &cobra.Command{
+	Use:   "reserve <title>",
+	Short: "Reserve the next ADR id",
+	Long: `Reserve the next ADR id for an ADR you haven't written yet.
+
+Reservations are kept in the file set by "adr.reservations" in your .rex.yaml
+config. Commit it so ADRs on other branches skip the reserved id. Creating an
+ADR with the same title uses the reserved id. For example:
+
+rex adr reserve "Use Postgres" -a "Donald Gifford"
+rex adr create -t "Use Postgres" -a "Donald Gifford"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		rex := rex.New()
+		r, err := rex.Reserve(args[0], author, time.Now().Format(time.DateOnly))
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		cmd.Printf("reserved ADR %d for %q\n", r.ID, r.Title)
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrReserveCmd)
+
+	adrReserveCmd.Flags().
+		StringVarP(&author, "author", "a", "", "Author for ADR")
+}
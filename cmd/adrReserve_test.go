@@ -0,0 +1,98 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrReserveCMD(t *testing.T) {
+	reservePath := "tests/reserve/docs/adr/"
+	err := createTestFolder(reservePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = createTestADRFile(reservePath + "1-First.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := "adr:\n  path: " + reservePath + "\n  index_page: README.md\n  reservations: tests/reserve/reservations.yaml\n"
+	err = os.WriteFile("tests/.reserve-rex.yaml", []byte(config), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "not_set",
+			output: "no reservation file set, set adr.reservations in your .rex.yaml\n",
+			setArgs: []string{
+				"--config=tests/.rex.yaml",
+				"adr",
+				"reserve",
+				"Reserved",
+			},
+		},
+		{
+			name:   "reserve",
+			output: "reserved ADR 2 for \"Reserved\"\n",
+			setArgs: []string{
+				"--config=tests/.reserve-rex.yaml",
+				"adr",
+				"reserve",
+				"Reserved",
+				"-a=TESTER",
+			},
+		},
+		{
+			name:   "already_reserved",
+			output: "ADR 2 is already reserved for \"Reserved\"\n",
+			setArgs: []string{
+				"--config=tests/.reserve-rex.yaml",
+				"adr",
+				"reserve",
+				"Reserved",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+}
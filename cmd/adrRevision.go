@@ -22,37 +22,47 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
 )
 
+var note string
+
 // adrRevisionCmd represents the adrRevision command
 var adrRevisionCmd = &cobra.Command{
-	Use:   "adrRevision",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
+	Use:   "revision <id>",
+	Short: "Create a new revision of an ADR",
+	Long: `Create a new revision of an existing ADR. The current version of the
+ADR is bumped, the last update is set to today and the note is added to the
+revision history of the ADR. The rest of the ADR is kept as written.
+
+rex adr revision 3 -m "Moved to managed Postgres"
+`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		// TODO: create this
-		fmt.Println("adrRevision called")
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			cmd.Printf("invalid ADR id: %s\n", args[0])
+			return
+		}
+
+		rex := rex.New()
+		a, err := rex.ReviseADR(id, note)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		cmd.Printf("%s revised to %s\n", a.Content.Title, a.Content.Version)
 	},
 }
 
 func init() {
 	adrCmd.AddCommand(adrRevisionCmd)
 
-	// Here you will define your flags and configuration settings.
-
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// adrRevisionCmd.PersistentFlags().String("foo", "", "A help for foo")
-
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// adrRevisionCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	adrRevisionCmd.Flags().
+		StringVarP(&note, "message", "m", "", "Note describing the revision")
 }
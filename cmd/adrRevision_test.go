@@ -0,0 +1,95 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrRevisionCMD(t *testing.T) {
+	revisionPath := "tests/revision/docs/adr/"
+	err := createTestFolder(revisionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(
+		revisionPath+"1-Revision.md",
+		[]byte("# Revision\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | TESTER | 2025-01-05 | N/A | v0.0.1 |\n\n## Decision Outcome\n"),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := time.Now().Format(time.DateOnly)
+	tests := map[string]struct {
+		file    string
+		content string
+		output  string
+		setArgs []string
+	}{
+		"revision": {
+			file: revisionPath + "1-Revision.md",
+			content: fmt.Sprintf(
+				"---\nid: 1\ntitle: Revision\nstatus: Draft\nauthors:\n  - TESTER\ndate: \"2025-01-05\"\nupdated: \"%s\"\nversion: v0.0.2\n---\n\n# Revision\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | TESTER | 2025-01-05 | %s | v0.0.2 |\n\n## Decision Outcome\n\n## Revision History\n\n- v0.0.2 (%s): Updated outcome\n",
+				d,
+				d,
+				d,
+			),
+			output: "Revision revised to v0.0.2\n",
+			setArgs: []string{
+				"--config=tests/.revision-rex.yaml",
+				"adr",
+				"revision",
+				"1",
+				"--message=Updated outcome",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+
+			b, err := ReadTestFile(test.file)
+			if err != nil {
+				t.Errorf(
+					"error opening test file: %v, err: %v",
+					test.file,
+					err.Error(),
+				)
+			}
+			assert.Equal(t, test.content, string(b), "")
+		})
+	}
+}
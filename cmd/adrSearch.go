@@ -0,0 +1,186 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+// highlightStart and highlightEnd are the ANSI codes matches are wrapped in
+// when writing to a terminal.
+const (
+	highlightStart = "\x1b[1;33m"
+	highlightEnd   = "\x1b[0m"
+)
+
+var (
+	searchOutput string
+	limit        int
+)
+
+// searchRecord is the data output for each result by the search command
+type searchRecord struct {
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	File    string `json:"file"`
+	Score   int    `json:"score"`
+	Snippet string `json:"snippet"`
+}
+
+// adrSearchCmd represents the adrSearch command
+var adrSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the ADRs in the ADR path",
+	Long: `Search the titles, bodies and metadata of the ADRs found in the path
+specified in the .rex.yaml config. Every word in the query must be found in
+an ADR, use double quotes to search for a phrase. When the query is split
+over several arguments, an argument with spaces is searched as a phrase.
+Results are ranked with title and tag matches first.
+
+The query can filter on fields with "status:", "tag:" and "author:". For
+example:
+
+rex adr search cache
+rex adr search '"event sourcing" status:accepted tag:database author:alice'
+
+Output defaults to text and can be set to json with '--output, -o'. The
+number of results is set with '--limit, -n', 0 shows every result.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query := searchQuery(args)
+
+		rex := rex.New()
+		results, err := rex.Search(query)
+		err = warnSkipped(cmd, err)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		if limit > 0 && len(results) > limit {
+			results = results[:limit]
+		}
+
+		w := cmd.OutOrStdout()
+		switch searchOutput {
+		case "text":
+			if len(results) == 0 {
+				cmd.Printf("no ADRs found matching %q\n", query)
+				return
+			}
+			err = writeResults(w, results, adr.ParseQuery(query).Terms, useColor(w))
+		case "json":
+			err = writeResultsJSON(w, results)
+		default:
+			err = fmt.Errorf("unknown output %q, must be one of: text, json", searchOutput)
+		}
+		if err != nil {
+			cmd.Println(err.Error())
+		}
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrSearchCmd)
+
+	adrSearchCmd.Flags().
+		StringVarP(&searchOutput, "output", "o", "text", "Output format: text or json")
+	adrSearchCmd.Flags().
+		IntVarP(&limit, "limit", "n", 10, "Maximum number of results, 0 shows every result")
+}
+
+// searchQuery returns the query in args. A single arg is the query as it
+// was written, several args are joined quoting args containing spaces so
+// they are searched as a phrase.
+func searchQuery(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	parts := make([]string, 0, len(args))
+	for _, a := range args {
+		if strings.ContainsAny(a, " \t") && !strings.Contains(a, `"`) {
+			a = `"` + a + `"`
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
+
+// writeResults writes each result with its snippet, highlighting the terms
+// when color is set.
+func writeResults(w io.Writer, results []adr.SearchResult, terms []string, color bool) error {
+	mark := func(s string) string {
+		if !color {
+			return s
+		}
+		return adr.Highlight(s, terms, highlightStart, highlightEnd)
+	}
+
+	for i, r := range results {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		a := r.ADR
+		_, err := fmt.Fprintf(w, "ADR %d: %s [%s]\n  %s\n", a.ID, mark(a.Content.Title), a.Content.Status, a.File)
+		if err != nil {
+			return err
+		}
+		if r.Snippet != "" {
+			if _, err := fmt.Fprintf(w, "  %s\n", mark(r.Snippet)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeResultsJSON writes the results as a json list
+func writeResultsJSON(w io.Writer, results []adr.SearchResult) error {
+	records := make([]searchRecord, 0, len(results))
+	for _, r := range results {
+		records = append(records, searchRecord{
+			ID:      r.ADR.ID,
+			Title:   r.ADR.Content.Title,
+			Status:  r.ADR.Content.Status,
+			File:    r.ADR.File,
+			Score:   r.Score,
+			Snippet: r.Snippet,
+		})
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(records)
+}
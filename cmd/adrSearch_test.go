@@ -0,0 +1,160 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrSearchCMD(t *testing.T) {
+	searchPath := "tests/search/docs/adr/"
+	err := createTestFolder(searchPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"1-use-postgres.md": "---\nid: 1\ntitle: Use Postgres\nstatus: Accepted\ntags: [database]\n---\n# Use Postgres\n\nWe store everything in Postgres.\n",
+		"2-cache.md":        "---\nid: 2\ntitle: Cache sessions\nstatus: Proposed\n---\n# Cache sessions\n\nSessions in Postgres add load to the database.\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(searchPath+name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "text",
+			output: "ADR 1: Use Postgres [Accepted]\n  tests/search/docs/adr/1-use-postgres.md\n  We store everything in Postgres.\n\nADR 2: Cache sessions [Proposed]\n  tests/search/docs/adr/2-cache.md\n  Sessions in Postgres add load to the database.\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				"postgres",
+				"--output=text",
+				"--limit=10",
+			},
+		},
+		{
+			name:   "limit",
+			output: "ADR 1: Use Postgres [Accepted]\n  tests/search/docs/adr/1-use-postgres.md\n  We store everything in Postgres.\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				"postgres",
+				"--output=text",
+				"--limit=1",
+			},
+		},
+		{
+			name:   "phrase_json",
+			output: "[\n  {\n    \"id\": 2,\n    \"title\": \"Cache sessions\",\n    \"status\": \"Proposed\",\n    \"file\": \"tests/search/docs/adr/2-cache.md\",\n    \"score\": 1,\n    \"snippet\": \"Sessions in Postgres add load to the database.\"\n  }\n]\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				`"the database"`,
+				"--output=json",
+				"--limit=10",
+			},
+		},
+		{
+			name:   "filters",
+			output: "ADR 1: Use Postgres [Accepted]\n  tests/search/docs/adr/1-use-postgres.md\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				"status:accepted",
+				"tag:database",
+				"--output=text",
+			},
+		},
+		{
+			name:   "quoted_filters",
+			output: "ADR 1: Use Postgres [Accepted]\n  tests/search/docs/adr/1-use-postgres.md\n  We store everything in Postgres.\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				"status:accepted postgres",
+				"--output=text",
+			},
+		},
+		{
+			name:   "phrase_arg",
+			output: "ADR 2: Cache sessions [Proposed]\n  tests/search/docs/adr/2-cache.md\n  Sessions in Postgres add load to the database.\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				"the database",
+				"sessions",
+				"--output=text",
+			},
+		},
+		{
+			name:   "no_results",
+			output: "no ADRs found matching \"mysql\"\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				"mysql",
+				"--output=text",
+			},
+		},
+		{
+			name:   "unknown_output",
+			output: "unknown output \"xml\", must be one of: text, json\n",
+			setArgs: []string{
+				"--config=tests/.search-rex.yaml",
+				"adr",
+				"search",
+				"postgres",
+				"--output=xml",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/markdown"
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+var (
+	raw        bool
+	showOutput string
+	width      int
+)
+
+// adrDocument is the data output for an ADR by the show command
+type adrDocument struct {
+	ID        int           `json:"id"`
+	Title     string        `json:"title"`
+	Status    string        `json:"status"`
+	Author    string        `json:"author"`
+	Created   string        `json:"created"`
+	Updated   string        `json:"updated"`
+	Version   string        `json:"version"`
+	Tags      []string      `json:"tags"`
+	Deciders  []string      `json:"deciders"`
+	Relations []adrRelation `json:"relations"`
+	File      string        `json:"file"`
+	Body      string        `json:"body"`
+}
+
+// adrRelation is the data output for each relation of an ADR
+type adrRelation struct {
+	Type  string `json:"type"`
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	File  string `json:"file"`
+}
+
+// adrShowCmd represents the adrShow command
+var adrShowCmd = &cobra.Command{
+	Use:   "show <id|slug>",
+	Short: "Show an ADR",
+	Long: `Show a single ADR found by its id or the slug of its file name or
+title. Its metadata is printed first followed by the ADR rendered for the
+terminal. For example:
+
+rex adr show 3
+rex adr show use-postgres
+
+Pass '--raw' to print the file as it is on disk, or '--output json' to
+print the ADR as json. Text is wrapped at '--width', defaulting to $COLUMNS
+or 80. Color is used when writing to a terminal unless $NO_COLOR is set.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		rex := rex.New()
+		a, err := rex.FindADR(args[0])
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		w := cmd.OutOrStdout()
+		switch {
+		case raw:
+			err = writeRaw(w, a)
+		case showOutput == "json":
+			err = writeJSON(w, a)
+		case showOutput == "text":
+			r := &markdown.Renderer{
+				Width: terminalWidth(width),
+				Color: useColor(w),
+			}
+			err = writeADR(w, a, r)
+		default:
+			err = fmt.Errorf("unknown output %q, must be one of: text, json", showOutput)
+		}
+		if err != nil {
+			cmd.Println(err.Error())
+		}
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrShowCmd)
+
+	adrShowCmd.Flags().
+		BoolVar(&raw, "raw", false, "Print the ADR file as it is on disk")
+	adrShowCmd.Flags().
+		StringVarP(&showOutput, "output", "o", "text", "Output format: text or json")
+	adrShowCmd.Flags().
+		IntVarP(&width, "width", "w", 0, "Column to wrap text at, defaults to $COLUMNS or 80")
+}
+
+// writeRaw writes the ADR file unchanged
+func writeRaw(w io.Writer, a *adr.ADR) error {
+	b, err := os.ReadFile(filepath.Clean(a.File))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// writeJSON writes the ADR's metadata and body as json
+func writeJSON(w io.Writer, a *adr.ADR) error {
+	doc := adrDocument{
+		ID:        a.ID,
+		Title:     a.Content.Title,
+		Status:    a.Content.Status,
+		Author:    a.Content.Author,
+		Created:   a.Content.Date,
+		Updated:   a.Content.Updated,
+		Version:   a.Content.Version,
+		Tags:      a.Content.Tags,
+		Deciders:  a.Content.Deciders,
+		Relations: make([]adrRelation, 0, len(a.Content.Relations)),
+		File:      a.File,
+		Body:      a.Body,
+	}
+	for _, r := range a.Content.Relations {
+		doc.Relations = append(doc.Relations, adrRelation{
+			Type:  r.Type,
+			ID:    r.ID,
+			Title: r.Title,
+			File:  r.File,
+		})
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(doc)
+}
+
+// writeADR writes the ADR's metadata followed by its body rendered by r
+func writeADR(w io.Writer, a *adr.ADR, r *markdown.Renderer) error {
+	title := fmt.Sprintf("ADR %d: %s", a.ID, a.Content.Title)
+	if _, err := fmt.Fprintln(w, r.Render("# "+title)); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fields := [][2]string{
+		{"Status", a.Content.Status},
+		{"Author", a.Content.Author},
+		{"Deciders", strings.Join(a.Content.Deciders, ", ")},
+		{"Created", a.Content.Date},
+		{"Updated", a.Content.Updated},
+		{"Version", a.Content.Version},
+		{"Tags", strings.Join(a.Content.Tags, ", ")},
+	}
+	for _, rel := range a.Content.Relations {
+		fields = append(fields, [2]string{
+			rel.Label(),
+			fmt.Sprintf("ADR %d: %s", rel.ID, rel.Title),
+		})
+	}
+	fields = append(fields, [2]string{"File", a.File})
+
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", f[0], f[1])
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	body := r.Render(a.Prose())
+	if body == "" {
+		return nil
+	}
+	_, err := fmt.Fprint(w, "\n"+body)
+	return err
+}
+
+// terminalWidth returns the width to wrap text at, set is used when given
+// otherwise $COLUMNS.
+func terminalWidth(set int) int {
+	if set > 0 {
+		return set
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return markdown.DefaultWidth
+}
+
+// useColor reports if w is a terminal and $NO_COLOR is not set.
+func useColor(w io.Writer) bool {
+	return os.Getenv("NO_COLOR") == "" && isTerminal(w)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrShowCMD(t *testing.T) {
+	showPath := "tests/show/docs/adr/"
+	err := createTestFolder(showPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adrFile := "---\nid: 1\ntitle: Use Postgres\nstatus: Accepted\nauthor: Alice\ndate: \"2025-01-05\"\ntags:\n  - db\n---\n# Use Postgres\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Accepted | Alice | 2025-01-05 | N/A | v0.0.1 |\n\n## Decision\n\nWe will use **Postgres** for storage.\n"
+	err = os.WriteFile(showPath+"1-use-postgres.md", []byte(adrFile), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "text",
+			output: "ADR 1: Use Postgres\n===================\n\nStatus   Accepted\nAuthor   Alice\nCreated  2025-01-05\nVersion  v0.0.1\nTags     db\nFile     tests/show/docs/adr/1-use-postgres.md\n\nDecision\n--------\n\nWe will use Postgres for\nstorage.\n",
+			setArgs: []string{
+				"--config=tests/.show-rex.yaml",
+				"adr",
+				"show",
+				"use-postgres",
+				"--raw=false",
+				"--output=text",
+				"--width=25",
+			},
+		},
+		{
+			name:   "raw",
+			output: adrFile,
+			setArgs: []string{
+				"--config=tests/.show-rex.yaml",
+				"adr",
+				"show",
+				"1",
+				"--raw",
+			},
+		},
+		{
+			name:   "json",
+			output: "{\n  \"id\": 1,\n  \"title\": \"Use Postgres\",\n  \"status\": \"Accepted\",\n  \"author\": \"Alice\",\n  \"created\": \"2025-01-05\",\n  \"updated\": \"\",\n  \"version\": \"v0.0.1\",\n  \"tags\": [\n    \"db\"\n  ],\n  \"deciders\": null,\n  \"relations\": [],\n  \"file\": \"tests/show/docs/adr/1-use-postgres.md\",\n  \"body\": \"# Use Postgres\\n\\n| Status | Author |  Created | Last Update | Current Version |\\n| ------ | ------ | -------- | ----------- | --------------- |\\n| Accepted | Alice | 2025-01-05 | N/A | v0.0.1 |\\n\\n## Decision\\n\\nWe will use **Postgres** for storage.\\n\"\n}\n",
+			setArgs: []string{
+				"--config=tests/.show-rex.yaml",
+				"adr",
+				"show",
+				"1",
+				"--raw=false",
+				"--output=json",
+			},
+		},
+		{
+			name:   "unknown_output",
+			output: "unknown output \"xml\", must be one of: text, json\n",
+			setArgs: []string{
+				"--config=tests/.show-rex.yaml",
+				"adr",
+				"show",
+				"1",
+				"--raw=false",
+				"--output=xml",
+			},
+		},
+		{
+			name:   "not_found",
+			output: "no ADR found matching \"2\" in tests/show/docs/adr/\n",
+			setArgs: []string{
+				"--config=tests/.show-rex.yaml",
+				"adr",
+				"show",
+				"2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+// adrStatusCmd represents the adrStatus command
+var adrStatusCmd = &cobra.Command{
+	Use:   "status <id> <status>",
+	Short: "Change the status of an ADR",
+	Long: `Change the status of an existing ADR and regenerate the index.
+
+The change must be allowed by the workflow set under "adr.workflow" in your
+.rex.yaml config. If no workflow is set the default is used:
+
+  Draft -> Proposed -> Accepted -> Deprecated/Superseded
+
+Draft and Proposed ADRs can also be Rejected. For example:
+
+rex adr status 3 Accepted
+
+Passing '--force, -f' sets the status without checking the workflow.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			cmd.Printf("invalid ADR id: %s\n", args[0])
+			return
+		}
+
+		rex := rex.New()
+		a, err := rex.SetStatus(id, args[1], force)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		cmd.Printf("%s is now %s\n", a.Content.Title, a.Content.Status)
+
+		// UpdateIndex always tries to update and regenerate the index
+		err = rex.UpdateIndex(false)
+		if err != nil {
+			cmd.Println(err.Error())
+		}
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrStatusCmd)
+
+	adrStatusCmd.Flags().
+		BoolVarP(&force, "force", "f", false, "set the status without checking the workflow")
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrStatusCMD(t *testing.T) {
+	statusPath := "tests/status/docs/adr/"
+	err := createTestFolder(statusPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(
+		statusPath+"1-Status.md",
+		[]byte("# Status\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Draft | TESTER | 2025-01-05 | N/A | v0.0.1 |\n"),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a status change sets the last update to today
+	today := time.Now().Format(time.DateOnly)
+
+	// run in order as each step depends on the previous status
+	tests := []struct {
+		name    string
+		output  string
+		status  string
+		updated string
+		setArgs []string
+	}{
+		{
+			name:    "not_allowed",
+			output:  "ADR 1: cannot change status from Draft to Accepted, allowed: Proposed, Rejected\n",
+			status:  "Draft",
+			updated: "N/A",
+			setArgs: []string{
+				"--config=tests/.status-rex.yaml",
+				"adr",
+				"status",
+				"1",
+				"Accepted",
+			},
+		},
+		{
+			name:    "allowed",
+			output:  "Status is now Proposed\n",
+			status:  "Proposed",
+			updated: today,
+			setArgs: []string{
+				"--config=tests/.status-rex.yaml",
+				"adr",
+				"status",
+				"1",
+				"proposed",
+			},
+		},
+		{
+			name:    "force",
+			output:  "Status is now Superseded\n",
+			status:  "Superseded",
+			updated: today,
+			setArgs: []string{
+				"--config=tests/.status-rex.yaml",
+				"adr",
+				"status",
+				"1",
+				"Superseded",
+				"--force",
+			},
+		},
+		{
+			name:    "invalid_id",
+			output:  "invalid ADR id: one\n",
+			status:  "Superseded",
+			updated: today,
+			setArgs: []string{
+				"--config=tests/.status-rex.yaml",
+				"adr",
+				"status",
+				"one",
+				"Accepted",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			force = false
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+
+			b, err := ReadTestFile(statusPath + "1-Status.md")
+			assert.Nil(t, err, "")
+			assert.Contains(t, string(b), "| "+test.status+" | TESTER | 2025-01-05 | "+test.updated+" |", "")
+			if test.updated != "N/A" {
+				assert.Contains(t, string(b), "updated: \""+test.updated+"\"\n", "")
+			}
+		})
+	}
+}
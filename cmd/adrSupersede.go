@@ -0,0 +1,80 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+// relatedID is the id of the other ADR in a relationship
+var relatedID int
+
+// adrSupersedeCmd represents the adrSupersede command
+var adrSupersedeCmd = &cobra.Command{
+	Use:   "supersede <id> --by <id>",
+	Short: "Mark an ADR as superseded by another ADR",
+	Long: `Record that an ADR is superseded by a newer ADR. Both ADRs link to
+each other under "Related ADRs", the old ADR's status is changed to Superseded
+and the index is regenerated. For example:
+
+rex adr supersede 3 --by 7
+
+The status change must be allowed by the workflow in your .rex.yaml config.
+Passing '--force, -f' sets the status without checking the workflow.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			cmd.Printf("invalid ADR id: %s\n", args[0])
+			return
+		}
+
+		rex := rex.New()
+		a, err := rex.Supersede(id, relatedID, force)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		cmd.Printf("%s is superseded by ADR %d\n", a.Content.Title, relatedID)
+
+		// UpdateIndex always tries to update and regenerate the index
+		err = rex.UpdateIndex(false)
+		if err != nil {
+			cmd.Println(err.Error())
+		}
+	},
+}
+
+func init() {
+	adrCmd.AddCommand(adrSupersedeCmd)
+
+	adrSupersedeCmd.Flags().
+		IntVarP(&relatedID, "by", "b", 0, "id of the ADR superseding this one")
+	adrSupersedeCmd.Flags().
+		BoolVarP(&force, "force", "f", false, "set the status without checking the workflow")
+	_ = adrSupersedeCmd.MarkFlagRequired("by")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdrSupersedeCMD(t *testing.T) {
+	supersedePath := "tests/relate/docs/adr/"
+	err := createRelateADRs(supersedePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "not_allowed",
+			output: "ADR 2: cannot change status from Draft to Superseded, allowed: Proposed, Rejected\n",
+			setArgs: []string{
+				"--config=tests/.relate-rex.yaml",
+				"adr",
+				"supersede",
+				"2",
+				"--by=1",
+			},
+		},
+		{
+			name:   "supersede",
+			output: "Old is superseded by ADR 2\n",
+			setArgs: []string{
+				"--config=tests/.relate-rex.yaml",
+				"adr",
+				"supersede",
+				"1",
+				"--by=2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			force = false
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+
+	// superseding changes the status, which updates the ADR
+	today := time.Now().Format(time.DateOnly)
+	b, err := ReadTestFile(supersedePath + "1-Old.md")
+	assert.Nil(t, err, "")
+	assert.Equal(
+		t,
+		"---\nid: 1\ntitle: Old\nstatus: Superseded\nauthors:\n  - TESTER\nupdated: \""+today+"\"\nsuperseded_by:\n  - 2\n---\n\n# Old\n\n| Status | Author |\n| ------ | ------ |\n| Superseded | TESTER |\n\n## Related ADRs\n\n- Superseded by [ADR 2: New](2-New.md)\n",
+		string(b),
+		"",
+	)
+}
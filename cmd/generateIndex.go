@@ -39,6 +39,11 @@ Create index listed from .rex.yaml config file:
 Regenerate index listed from .rex.yaml config file:
   rex config generate index --force
 
+Only the region of the index between the rex:index:start and
+rex:index:end markers is rewritten, so prose written around it is kept.
+An index without the markers has the region added to its end, passing
+'--force, -f' overwrites the whole index instead.
+
 The index subcommand looks at the .rex.yaml config file to 
 see where to save the index file, name, and what template to use.`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -0,0 +1,80 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+var pagesPath string
+
+// pagesGenerateCmd represents the pages generate command
+var pagesGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a Jekyll site for GitHub Pages",
+	Long: `generate writes a Jekyll site for GitHub Pages to "pages.path" in
+your .rex.yaml, or the path passed with '--output, -o':
+
+  _config.yml        the Jekyll config, "pages.web.config"
+  _layouts/          the "pages.web.layout.default" and "pages.web.layout.adr" layouts
+  index.md           a page listing every ADR, "pages.index"
+  adr/               a page for every ADR
+  search.json        the search index, searched by search.js from the search box
+  feed.xml           an Atom feed of decision changes, if "feed.url" is set, and
+                     rss.xml an RSS 2.0 feed with "feed.rss: true"
+
+The Jekyll config and layouts are only created if they don't exist, so
+they can be changed by hand. Passing '--force, -f' overwrites them. The
+ADR pages are written every time, pages of ADRs that no longer exist are
+removed and only the region of the index page between the rex:index
+markers is updated.
+
+If "templates.enabled: true" is set, templates in a "gh" directory in the
+templates path are used over the built in ones.
+
+  rex pages generate
+  rex pages generate -o site/`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rex := rex.New()
+
+		files, err := rex.GeneratePages(pagesPath, force)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		for _, f := range files {
+			cmd.Printf("wrote %s\n", f)
+		}
+	},
+}
+
+func init() {
+	pagesCmd.AddCommand(pagesGenerateCmd)
+
+	pagesGenerateCmd.Flags().
+		StringVarP(&pagesPath, "output", "o", "", "directory to write the site to, defaults to pages.path")
+	pagesGenerateCmd.Flags().
+		BoolVarP(&force, "force", "f", false, "overwrite the Jekyll config and layouts")
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagesGenerateCMD(t *testing.T) {
+	adrPath := "tests/pages/docs/adr/"
+	err := createTestFolder(adrPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = createConfigFile("tests/.pages-rex.yaml", adrPath, false, "tests/pages/docs/templates/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(
+		adrPath+"1-use-go.md",
+		[]byte("---\nid: 1\ntitle: Use Go\nstatus: Accepted\n---\n# Use Go\n"),
+		0o644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		enabled bool
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "disabled",
+			output: "GitHub Pages are disabled, set enable_github_pages: true in your .rex.yaml\n",
+			setArgs: []string{
+				"--config=tests/.pages-rex.yaml",
+				"pages",
+				"generate",
+				"--output=tests/pages/site/",
+			},
+		},
+		{
+			name:    "generate",
+			enabled: true,
+			output:  "wrote tests/pages/site/_config.yml\nwrote tests/pages/site/_layouts/default.html\nwrote tests/pages/site/_layouts/adr.html\nwrote tests/pages/site/adr/1-use-go.md\nwrote tests/pages/site/search.js\nwrote tests/pages/site/search.json\nwrote tests/pages/site/index.md\n",
+			setArgs: []string{
+				"--config=tests/.pages-rex.yaml",
+				"pages",
+				"generate",
+				"--output=tests/pages/site/",
+			},
+		},
+		{
+			name:    "existing",
+			enabled: true,
+			output:  "wrote tests/pages/site/adr/1-use-go.md\nwrote tests/pages/site/search.js\nwrote tests/pages/site/search.json\nwrote tests/pages/site/index.md\n",
+			setArgs: []string{
+				"--config=tests/.pages-rex.yaml",
+				"pages",
+				"generate",
+				"--output=tests/pages/site/",
+				"--force=false",
+			},
+		},
+		{
+			name:    "force",
+			enabled: true,
+			output:  "wrote tests/pages/site/_config.yml\nwrote tests/pages/site/_layouts/default.html\nwrote tests/pages/site/_layouts/adr.html\nwrote tests/pages/site/adr/1-use-go.md\nwrote tests/pages/site/search.js\nwrote tests/pages/site/search.json\nwrote tests/pages/site/index.md\n",
+			setArgs: []string{
+				"--config=tests/.pages-rex.yaml",
+				"pages",
+				"generate",
+				"--output=tests/pages/site/",
+				"--force",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("enable_github_pages", test.enabled)
+			defer viper.Set("enable_github_pages", nil)
+
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+
+	b, err := os.ReadFile("tests/pages/site/adr/1-use-go.md")
+	assert.Nil(t, err, "")
+	assert.Equal(t, "---\nlayout: adr\ntitle: 'ADR 1: Use Go'\nadr: 1\nstatus: Accepted\n---\n\n# Use Go\n\n", string(b), "")
+}
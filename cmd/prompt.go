@@ -0,0 +1,122 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/donaldgifford/rex/internal/gitutil"
+)
+
+// gitUserName returns the user.name set in the git config, it is a
+// variable so tests can replace it.
+var gitUserName = gitutil.UserName
+
+// prompter asks the user for values, reading their answers a line at a time.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// newPrompter returns a prompter reading answers from in and writing
+// questions to out.
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// ask prints label and returns the answer, or def if the answer is empty.
+// io.EOF is returned with def when the input ended without an answer.
+func (p *prompter) ask(label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	answer := strings.TrimSpace(line)
+	if answer != "" {
+		return answer, nil
+	}
+	if err != nil {
+		fmt.Fprintln(p.out)
+		return def, err
+	}
+	return def, nil
+}
+
+// askRequired asks for label until a value is given.
+func (p *prompter) askRequired(label, def string) (string, error) {
+	required := fmt.Sprintf("%s is required", strings.ToLower(label))
+	for {
+		answer, err := p.ask(label, def)
+		if answer != "" {
+			return answer, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return "", errors.New(required)
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(p.out, required)
+	}
+}
+
+// askList asks for a comma separated list of values.
+func (p *prompter) askList(label string, def []string) ([]string, error) {
+	answer, err := p.ask(label+" (comma separated)", strings.Join(def, ", "))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var values []string
+	for _, v := range strings.Split(answer, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// isTerminal reports if v is a file connected to a terminal.
+func isTerminal(v any) bool {
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
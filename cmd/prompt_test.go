@@ -0,0 +1,108 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompterAsk(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		def      string
+		expected string
+		output   string
+		err      error
+	}{
+		"answer":      {input: " Alice \n", def: "Bob", expected: "Alice", output: "Author [Bob]: "},
+		"default":     {input: "\n", def: "Bob", expected: "Bob", output: "Author [Bob]: "},
+		"no_newline":  {input: "Alice", expected: "Alice", output: "Author: "},
+		"eof":         {input: "", def: "Bob", expected: "Bob", output: "Author [Bob]: \n", err: io.EOF},
+		"eof_default": {input: "", expected: "", output: "Author: \n", err: io.EOF},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := newPrompter(strings.NewReader(test.input), out)
+			answer, err := p.ask("Author", test.def)
+			assert.Equal(t, test.err, err, "")
+			assert.Equal(t, test.expected, answer, "")
+			assert.Equal(t, test.output, out.String(), "")
+		})
+	}
+}
+
+func TestPrompterAskList(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		def      []string
+		expected []string
+	}{
+		"list":    {input: "db, api ,,\n", expected: []string{"db", "api"}},
+		"default": {input: "\n", def: []string{"db"}, expected: []string{"db"}},
+		"empty":   {input: "", expected: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newPrompter(strings.NewReader(test.input), io.Discard)
+			values, err := p.askList("Tags", test.def)
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, values, "")
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	file, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	tests := map[string]struct {
+		v any
+	}{
+		"dev_null": {v: devNull},
+		"file":     {v: file},
+		"reader":   {v: strings.NewReader("")},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, isTerminal(test.v), "")
+		})
+	}
+}
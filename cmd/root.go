@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/donaldgifford/rex/internal/config"
 	"github.com/donaldgifford/rex/internal/install"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -125,4 +126,9 @@ func initConfig() {
 		}
 		os.Exit(1)
 	}
+
+	// settings that can't be read are ignored, so warn about them
+	if err := config.NewRexConfig().Err(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err.Error())
+	}
 }
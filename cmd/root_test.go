@@ -214,6 +214,105 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	err = createConfigFile(
+		"tests/.revision-rex.yaml",
+		"tests/revision/docs/adr/",
+		false,
+		"tests/revision/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.list-rex.yaml",
+		"tests/list/docs/adr/",
+		false,
+		"tests/list/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.status-rex.yaml",
+		"tests/status/docs/adr/",
+		false,
+		"tests/status/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.relate-rex.yaml",
+		"tests/relate/docs/adr/",
+		false,
+		"tests/relate/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.renumber-rex.yaml",
+		"tests/renumber/docs/adr/",
+		false,
+		"tests/renumber/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.show-rex.yaml",
+		"tests/show/docs/adr/",
+		false,
+		"tests/show/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.search-rex.yaml",
+		"tests/search/docs/adr/",
+		false,
+		"tests/search/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.create-rex.yaml",
+		"tests/create/docs/adr/",
+		false,
+		"tests/create/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	err = createConfigFile(
+		"tests/.fields-rex.yaml",
+		"tests/fields/docs/adr/",
+		false,
+		"tests/fields/docs/templates/",
+	)
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
 	// setup some test files
 	err = createTestADRFile(fmt.Sprintf("%s%s", adrDocsPath, "1-test1.md"))
 	if err != nil {
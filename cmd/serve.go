@@ -0,0 +1,78 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+var (
+	servePort int
+	serveHost string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Preview your ADRs as a site on localhost",
+	Long: `serve builds the same HTML site as 'rex site build' and serves it on
+localhost, port 8080 or the port passed with '--port, -p'.
+
+The ADR path and, if "templates.enabled: true" is set, the templates path
+are watched. When an ADR or template changes the site is rebuilt and open
+pages reload. If a rebuild fails the error is printed and the last site
+built is still served.
+
+The site is built to a temporary directory that is removed when serve is
+stopped with Ctrl+C.
+
+  rex serve
+  rex serve --port 3000`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rex := rex.New()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+		err := rex.Serve(ctx, addr, cmd.OutOrStdout())
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8080, "port to serve the site on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "localhost", "host to serve the site on")
+}
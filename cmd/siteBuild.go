@@ -0,0 +1,77 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/rex/internal/rex"
+)
+
+var sitePath string
+
+// siteBuildCmd represents the site build command
+var siteBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build a static HTML site of your ADRs",
+	Long: `build writes a standalone HTML site, with no Jekyll or other tools
+needed to serve it, to "site.path" in your .rex.yaml or the path passed
+with '--out, -o':
+
+  index.html   a page listing every ADR, grouped as the ADR index is
+  adr/         a page for every ADR with its metadata, status, related
+               ADRs and links to the previous and next ADR
+  style.css    the style sheet
+  search.json  the search index, searched by search.js from the search box
+  feed.xml     an Atom feed of decision changes, if "feed.url" is set, and
+               rss.xml an RSS 2.0 feed with "feed.rss: true"
+
+Every page has a sidebar linking to every ADR. Links between ADRs are
+pointed at their pages. Pages of ADRs that no longer exist are removed.
+
+If "templates.enabled: true" is set, layout.html, adr.html, index.html,
+style.css and search.js in a "site" directory in the templates path are
+used over the built in ones.
+
+  rex site build
+  rex site build --out public/`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rex := rex.New()
+
+		files, err := rex.BuildSite(sitePath)
+		if err != nil {
+			cmd.Println(err.Error())
+			return
+		}
+
+		for _, f := range files {
+			cmd.Printf("wrote %s\n", f)
+		}
+	},
+}
+
+func init() {
+	siteCmd.AddCommand(siteBuildCmd)
+
+	siteBuildCmd.Flags().
+		StringVarP(&sitePath, "out", "o", "", "directory to write the site to, defaults to site.path")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteBuildCMD(t *testing.T) {
+	adrPath := "tests/site/docs/adr/"
+	err := createTestFolder(adrPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = createConfigFile("tests/.site-rex.yaml", adrPath, false, "tests/site/docs/templates/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(
+		adrPath+"1-use-go.md",
+		[]byte("---\nid: 1\ntitle: Use Go\nstatus: Accepted\n---\n# Use Go\n\nWe use **Go**.\n"),
+		0o644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		setArgs []string
+	}{
+		{
+			name:   "build",
+			output: "wrote tests/site/public/style.css\nwrote tests/site/public/search.js\nwrote tests/site/public/search.json\nwrote tests/site/public/index.html\nwrote tests/site/public/adr/1-use-go.html\n",
+			setArgs: []string{
+				"--config=tests/.site-rex.yaml",
+				"site",
+				"build",
+				"--out=tests/site/public/",
+			},
+		},
+		{
+			name:   "adr path",
+			output: "the site can't be written to tests/site/docs/, it is the ADR path, set site.path to another directory\n",
+			setArgs: []string{
+				"--config=tests/.site-rex.yaml",
+				"site",
+				"build",
+				"--out=tests/site/docs/",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(test.setArgs)
+			err := rootCmd.Execute()
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.output, buf.String(), "")
+		})
+	}
+
+	b, err := os.ReadFile("tests/site/public/adr/1-use-go.html")
+	assert.Nil(t, err, "")
+	assert.Contains(t, string(b), "<p>We use <strong>Go</strong>.</p>", "")
+}
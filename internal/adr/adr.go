@@ -20,26 +20,43 @@ OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 THE SOFTWARE.
 */
 // Package adr provides interfaces and methods for creating and managing adr's
-//
-// Currently this package does not implement the Revision method as it still has
-// to be built.
 package adr
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"slices"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/donaldgifford/rex/internal/fileutil"
 )
 
+// initialVersion is the version of a newly created ADR.
+const initialVersion = "v0.0.1"
+
+// errNoTitle is returned when creating an ADR without a title.
+var errNoTitle = errors.New("an ADR title is required")
+
 // An IADR creates ADR's to use and update.
 type IADR interface {
-	Create(content *Content) (*ADR, error)
-	Revision(id int) (*ADR, error)
+	Create(content *Content) (*ADR, *Reservation, error)
+	Revision(id int, note string) (*ADR, error)
+	SetStatus(id int, status string, force bool) (*ADR, error)
+	Relate(id int, relation string, other int) error
+	Supersede(old, by int, force bool) (*ADR, error)
+	Renumber(dryRun bool) ([]Renumbered, error)
+	Reserve(title, author, date string) (*Reservation, error)
+	Claim(r *Reservation) error
+	Find(ref string) (*ADR, error)
+	Search(query string) ([]SearchResult, error)
 	Id() (int, error)
+	List() ([]*ADR, error)
 	GetSettings() *ADRConfig
 }
 
@@ -49,34 +66,61 @@ func NewIADR() IADR {
 }
 
 // ADR is the data that is sent to the templates to be created
+//
+// File and Body are only set for ADR's that have been read from disk.
 type ADR struct {
 	Content Content
 	ID      int
 	Config  ADRConfig
+	File    string
+	Body    string
 }
 
 // Content is the input for creating a new ADR
+//
+// Template is the name of the template the ADR is created from, empty uses
+// the default template.
+//
+// Fields holds the values of the fields configured under "adr.fields",
+// see Field.
 type Content struct {
-	Title  string
-	Author string
-	Status string
-	Date   string
+	Title     string
+	Author    string
+	Status    string
+	Date      string
+	Updated   string
+	Version   string
+	Tags      []string
+	Deciders  []string
+	Relations []Relation
+	Template  string
+	Fields    map[string]any
 }
 
 // ADRConfig holds configuration for where ADR's are written to, what
 // the index page is, and if ADR's are added to the index page.
+//
+// IDWidth pads the id in new file names with zeros, 0 disables padding.
+// Reservations is the file ids are reserved in, empty disables reserving.
+// FilenamePattern is the template new file names are created from.
 type ADRConfig struct {
-	Path       string
-	IndexPage  string
-	AddToIndex bool
+	Path            string
+	IndexPage       string
+	AddToIndex      bool
+	IDWidth         int
+	Reservations    string
+	FilenamePattern string
 }
 
 // newADRConfig reads the configuration settings under "adr"
 func NewADRConfig() *ADRConfig {
 	return &ADRConfig{
-		Path:       viper.GetString("adr.path"),
-		IndexPage:  viper.GetString("adr.index_page"),
-		AddToIndex: viper.GetBool("adr.add_to_index"),
+		Path:            viper.GetString("adr.path"),
+		IndexPage:       viper.GetString("adr.index_page"),
+		AddToIndex:      viper.GetBool("adr.add_to_index"),
+		IDWidth:         viper.GetInt("adr.id_width"),
+		Reservations:    viper.GetString("adr.reservations"),
+		FilenamePattern: viper.GetString("adr.filename_pattern"),
 	}
 }
 
@@ -86,7 +130,8 @@ func (a *ADRConfig) GetSettings() *ADRConfig {
 }
 
 // GetAdrFilesNames returns a slice of strings containing the
-// file names found in the ADR Path minus the index page.
+// file names of the ADR's found in the ADR Path. Directories, the index
+// page and files not named like an ADR, e.g. "1-title.md", are skipped.
 // Returns error if path cannot be found.
 func (adr *ADR) GetAdrFilesNames() ([]string, error) {
 	var files []string
@@ -95,7 +140,10 @@ func (adr *ADR) GetAdrFilesNames() ([]string, error) {
 		return nil, err
 	}
 	for _, file := range fileInfo {
-		if file.Name() != "README.md" {
+		if file.IsDir() || file.Name() == adr.Config.IndexPage {
+			continue
+		}
+		if _, ok := fileID(file.Name()); ok {
 			files = append(files, file.Name())
 		}
 	}
@@ -104,54 +152,326 @@ func (adr *ADR) GetAdrFilesNames() ([]string, error) {
 }
 
 // Id is a helper function that returns the next int
-// to use as the ID for an ADR
+// to use as the ID for an ADR. Gaps left by removed ADR's are not reused
+// and reserved ids are skipped.
 func (adr *ADR) Id() (int, error) {
 	adrs, err := adr.GetAdrFilesNames()
 	if err != nil {
 		return 0, err
 	}
 
-	if len(adrs) == 0 {
-		return 1, nil
-	}
-
-	var fileNames []int
+	latestID := 0
 	for _, v := range adrs {
-		k := strings.Split(v, "-")[0]
-		a, err := strconv.Atoi(k)
-		if err != nil {
-			log.Fatal(err)
-		}
-		fileNames = append(fileNames, a)
+		id, _ := fileID(v)
+		latestID = max(latestID, id)
 	}
 
-	lastestID := slices.Max(fileNames)
+	reserved, err := adr.reservedIDs()
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range reserved {
+		latestID = max(latestID, id)
+	}
 
-	return lastestID + 1, nil
+	return latestID + 1, nil
 }
 
 // Create takes a content pointer and returns an ADR pointer and error.
-func (adr *ADR) Create(content *Content) (*ADR, error) {
+//
+// A title is required. The status defaults to the initial status of the
+// workflow and must be part of it.
+//
+// Fields must be configured under "adr.fields" and required fields must
+// have a value or a default.
+//
+// If an id was reserved for the title, the reserved id is used and the
+// reservation is returned. It is kept in the reservation file until it is
+// removed with Claim, after the ADR is written.
+func (adr *ADR) Create(content *Content) (*ADR, *Reservation, error) {
+	if strings.TrimSpace(content.Title) == "" {
+		return &ADR{}, nil, errNoTitle
+	}
+
+	workflow, err := NewWorkflow()
+	if err != nil {
+		return &ADR{}, nil, err
+	}
+
+	status := workflow.Initial
+	if content.Status != "" {
+		status = workflow.Status(content.Status)
+		if status == "" {
+			return &ADR{}, nil, fmt.Errorf(
+				"unknown status %q, must be one of: %s",
+				content.Status,
+				strings.Join(workflow.Statuses(), ", "),
+			)
+		}
+	}
+
+	fields, err := NewFields()
+	if err != nil {
+		return &ADR{}, nil, err
+	}
+
+	values, err := fieldValues(FieldsFor(fields, content.Template), content.Fields)
+	if err != nil {
+		return &ADR{}, nil, err
+	}
+
 	adrId, err := adr.Id()
 	if err != nil {
-		return &ADR{}, err
+		return &ADR{}, nil, err
+	}
+
+	reservation, err := adr.reservation(content.Title)
+	if err != nil {
+		return &ADR{}, nil, err
+	}
+	if reservation != nil {
+		adrId = reservation.ID
+	}
+
+	version := content.Version
+	if version == "" {
+		version = initialVersion
 	}
 
 	return &ADR{
 		Content: Content{
-			Title:  content.Title,
-			Author: content.Author,
-			Status: content.Status,
-			Date:   content.Date,
+			Title:    strings.TrimSpace(content.Title),
+			Author:   content.Author,
+			Status:   status,
+			Date:     content.Date,
+			Version:  version,
+			Tags:     content.Tags,
+			Deciders: content.Deciders,
+			Template: content.Template,
+			Fields:   values,
 		},
-		ID: adrId,
-	}, nil
+		ID:     adrId,
+		Config: adr.Config,
+	}, reservation, nil
+}
+
+// FindFile returns the path to the ADR file with the given id.
+// Returns error if no ADR with that id exists.
+func (adr *ADR) FindFile(id int) (string, error) {
+	adrs, err := adr.GetAdrFilesNames()
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range adrs {
+		if a, _ := fileID(v); a == id {
+			return filepath.Join(adr.Config.Path, v), nil
+		}
+	}
+
+	return "", fmt.Errorf("no ADR found with id %d in %s", id, adr.Config.Path)
+}
+
+// Revision loads the ADR with the given id, bumps its current version,
+// sets the last update to today and records note in the revision history.
+//
+// The file is rewritten in place and everything else in the body is kept
+// as the author wrote it.
+func (adr *ADR) Revision(id int, note string) (*ADR, error) {
+	err := adr.rewrite(id, func(a *ADR) error {
+		version, err := bumpVersion(a.Content.Version)
+		if err != nil {
+			return err
+		}
+
+		a.Content.Version = version
+		a.Content.Updated = time.Now().Format(time.DateOnly)
+		a.addToSection(
+			revisionHeading,
+			revisionEntry(version, a.Content.Updated, note),
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adr.Load(id)
+}
+
+// SetStatus changes the status of the ADR with the given id and sets its
+// last update to today. The change must be allowed by the configured
+// Workflow unless force is set.
+func (adr *ADR) SetStatus(id int, status string, force bool) (*ADR, error) {
+	current, err := adr.Load(id)
+	if err != nil {
+		return nil, err
+	}
+
+	next := status
+	if !force {
+		workflow, err := NewWorkflow()
+		if err != nil {
+			return nil, err
+		}
+
+		next, err = workflow.Transition(current.Content.Status, status)
+		if err != nil {
+			return nil, fmt.Errorf("ADR %d: %w", id, err)
+		}
+	}
+
+	err = adr.rewrite(id, func(a *ADR) error {
+		if a.Content.Status != next {
+			a.Content.Updated = time.Now().Format(time.DateOnly)
+		}
+		a.Content.Status = next
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adr.Load(id)
+}
+
+// rewrite loads the ADR with the given id, passes it to edit and writes
+// the edited ADR back to its file in place. The front matter and metadata
+// table are rendered from the edited Content.
+func (adr *ADR) rewrite(id int, edit func(a *ADR) error) error {
+	file, err := adr.FindFile(id)
+	if err != nil {
+		return err
+	}
+
+	return rewriteFile(file, edit)
+}
+
+// rewriteFile parses the ADR in file, passes it to edit and writes the
+// edited ADR back to file.
+func rewriteFile(file string, edit func(a *ADR) error) error {
+	a, err := Parse(file)
+	if err != nil {
+		return err
+	}
+
+	err = edit(a)
+	if err != nil {
+		return fmt.Errorf("%s: %w", a.File, err)
+	}
+
+	doc, err := a.Document()
+	if err != nil {
+		return fmt.Errorf("%s: %w", a.File, err)
+	}
+
+	return fileutil.WriteFile(a.File, func(w io.Writer) error {
+		_, err := io.WriteString(w, doc)
+		return err
+	})
+}
+
+// addToSection adds entry to the end of the section under heading in the
+// Body, creating the section if needed.
+func (adr *ADR) addToSection(heading, entry string) {
+	lines := strings.Split(adr.Body, "\n")
+	adr.Body = strings.Join(addToSection(lines, heading, entry), "\n")
+}
+
+// Load finds the ADR with the given id and parses it from disk.
+func (adr *ADR) Load(id int) (*ADR, error) {
+	file, err := adr.FindFile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := Parse(file)
+	if err != nil {
+		return nil, err
+	}
+	a.Config = adr.Config
+
+	return a, nil
 }
 
-// TODO: Revision takes the current ADR and creates a
-// revision for it.
-func (adr *ADR) Revision(id int) (*ADR, error) {
-	return nil, nil
+// SkippedError is returned by List with the ADR's it could parse when
+// some ADR files couldn't be parsed. Errs holds why each file was skipped.
+type SkippedError struct {
+	Errs []*ParseError
+}
+
+func (e *SkippedError) Error() string {
+	files := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		files = append(files, err.Error())
+	}
+	return fmt.Sprintf("%d ADR's couldn't be parsed:\n  %s", len(e.Errs), strings.Join(files, "\n  "))
+}
+
+func (e *SkippedError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// List parses every ADR in the ADR Path and returns them ordered by id.
+//
+// ADR files that can't be parsed are skipped, the ADR's that could be are
+// returned with a *SkippedError naming the skipped files. Callers decide
+// whether to carry on without them.
+func (adr *ADR) List() ([]*ADR, error) {
+	files, err := adr.GetAdrFilesNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var adrs []*ADR
+	var skipped []*ParseError
+	for _, f := range files {
+		a, err := Parse(filepath.Join(adr.Config.Path, f))
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			skipped = append(skipped, parseErr)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.Config = adr.Config
+		adrs = append(adrs, a)
+	}
+
+	slices.SortStableFunc(adrs, func(a, b *ADR) int {
+		return a.ID - b.ID
+	})
+
+	if len(skipped) > 0 {
+		return adrs, &SkippedError{Errs: skipped}
+	}
+	return adrs, nil
+}
+
+// Filter returns the ADR's matching status and author. Status must match
+// exactly and author only has to be part of the ADR's author, both ignoring
+// case. Empty values match every ADR.
+func Filter(adrs []*ADR, status, author string) []*ADR {
+	var filtered []*ADR
+	for _, a := range adrs {
+		if status != "" && !strings.EqualFold(a.Content.Status, status) {
+			continue
+		}
+		if author != "" && !strings.Contains(
+			strings.ToLower(a.Content.Author),
+			strings.ToLower(author),
+		) {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+	return filtered
 }
 
 // GetSettings returns the ADRConfig settings for the
@@ -268,7 +268,7 @@ func TestNewADRCreate(t *testing.T) {
 			Date:   d,
 		}
 
-		c, err := a.Create(&u)
+		c, _, err := a.Create(&u)
 		t.Run(name, func(t *testing.T) {
 			assert.Equal(t, test.content.Title, c.Content.Title, "")
 			assert.Equal(t, test.content.Author, c.Content.Author, "")
@@ -283,6 +283,35 @@ func TestNewADRCreate(t *testing.T) {
 	}
 }
 
+func TestCreateValidation(t *testing.T) {
+	viper.Set("adr.path", defaultAdrPath)
+
+	tests := map[string]struct {
+		content Content
+		title   string
+		status  string
+		err     bool
+	}{
+		"default_status": {content: Content{Title: " Title "}, title: "Title", status: "Draft"},
+		"status":         {content: Content{Title: "Title", Status: "proposed"}, title: "Title", status: "Proposed"},
+		"no_title":       {content: Content{Title: "  "}, err: true},
+		"unknown_status": {content: Content{Title: "Title", Status: "Done"}, err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, _, err := NewADR().Create(&test.content)
+			if test.err {
+				assert.Error(t, err, "")
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.title, a.Content.Title, "")
+			assert.Equal(t, test.status, a.Content.Status, "")
+		})
+	}
+}
+
 func TestADRGetSettings(t *testing.T) {
 	tests := map[string]struct {
 		path  string
@@ -317,6 +346,21 @@ func TestADRGetSettings(t *testing.T) {
 }
 
 func TestId(t *testing.T) {
+	// ADR's mixed with files and directories that aren't ADR's
+	mixedPath := "tests/id/mixed/"
+	for _, dir := range []string{mixedPath + "images/", "tests/id/empty/"} {
+		err := createTestFolder(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{".gitkeep", "index.md", "notes.md", "2-second.md", "0007-padded.md", "12-draft.txt"} {
+		err := createTestADRFile(mixedPath + f)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	tests := map[string]struct {
 		configPath  string
 		configIndex string
@@ -331,6 +375,20 @@ func TestId(t *testing.T) {
 			id:          3,
 			err:         false,
 		},
+		"mixed": {
+			configPath:  mixedPath,
+			configIndex: "index.md",
+			configAdd:   true,
+			id:          8,
+			err:         false,
+		},
+		"empty": {
+			configPath:  "tests/id/empty/",
+			configIndex: "README.md",
+			configAdd:   true,
+			id:          1,
+			err:         false,
+		},
 		"error": {
 			configPath:  "/path/to/adr",
 			configIndex: "index.md",
@@ -390,3 +448,254 @@ func TestNewIADR(t *testing.T) {
 		})
 	}
 }
+
+func TestRevision(t *testing.T) {
+	revisionPath := "tests/revision/adr/"
+	err := createTestFolder(revisionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(
+		revisionPath+"1-Revise-Me.md",
+		[]byte("# Revise Me\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | Author | 2025-01-05 | N/A | v0.0.1 |\n\n## Decision Outcome\n\nKeep this text\n"),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := time.Now().Format(time.DateOnly)
+	tests := map[string]struct {
+		id       int
+		note     string
+		version  string
+		expected string
+		err      bool
+	}{
+		"good": {
+			id:      1,
+			note:    "Changed the outcome",
+			version: "v0.0.2",
+			expected: fmt.Sprintf(
+				"---\nid: 1\ntitle: Revise Me\nstatus: Draft\nauthors:\n  - Author\ndate: \"2025-01-05\"\nupdated: \"%s\"\nversion: v0.0.2\n---\n\n# Revise Me\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | Author | 2025-01-05 | %s | v0.0.2 |\n\n## Decision Outcome\n\nKeep this text\n\n## Revision History\n\n- v0.0.2 (%s): Changed the outcome\n",
+				d,
+				d,
+				d,
+			),
+			err: false,
+		},
+		"missing": {
+			id:  9,
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.path", revisionPath)
+			a := NewADR()
+			actual, err := a.Revision(test.id, test.note)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, "Revise Me", actual.Content.Title, "")
+			assert.Equal(t, test.version, actual.Content.Version, "")
+			assert.Equal(t, d, actual.Content.Updated, "")
+
+			b, err := os.ReadFile(revisionPath + "1-Revise-Me.md")
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, string(b), "")
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	listPath := "tests/list/adr/"
+	err := createTestFolder(listPath + "images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"10-Tenth.md": "# Tenth\n",
+		"2-Second.md": "# Second\n",
+		"README.md":   "# ADR Index\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(listPath+name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	invalidPath := "tests/list-invalid/adr/"
+	err = createTestFolder(invalidPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"1-First.md":   "# First\n",
+		"2-Invalid.md": "---\nid: [\n---\n\n# Invalid\n",
+	} {
+		err = os.WriteFile(invalidPath+name, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		path    string
+		ids     []int
+		titles  []string
+		skipped []string
+		err     bool
+	}{
+		"good": {
+			path:   listPath,
+			ids:    []int{2, 10},
+			titles: []string{"Second", "Tenth"},
+			err:    false,
+		},
+		"invalid_skipped": {
+			path:    invalidPath,
+			ids:     []int{1},
+			titles:  []string{"First"},
+			skipped: []string{"tests/list-invalid/adr/2-Invalid.md"},
+			err:     false,
+		},
+		"bad_path": {
+			path: "/path/to/adr",
+			err:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.path", test.path)
+			viper.Set("adr.index_page", "README.md")
+			a := NewADR()
+			adrs, err := a.List()
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+				return
+			}
+
+			// the ADR's that could be parsed are returned with the skipped files
+			if len(test.skipped) > 0 {
+				var skipped *SkippedError
+				assert.ErrorAs(t, err, &skipped, "")
+
+				var files []string
+				for _, e := range skipped.Errs {
+					files = append(files, e.File)
+					assert.ErrorContains(t, e, "invalid front matter", "")
+				}
+				assert.Equal(t, test.skipped, files, "")
+			} else {
+				assert.Nil(t, err, "")
+			}
+
+			var ids []int
+			var titles []string
+			for _, a := range adrs {
+				ids = append(ids, a.ID)
+				titles = append(titles, a.Content.Title)
+			}
+			assert.Equal(t, test.ids, ids, "")
+			assert.Equal(t, test.titles, titles, "")
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	adrs := []*ADR{
+		{ID: 1, Content: Content{Status: "Accepted", Author: "Alice Smith"}},
+		{ID: 2, Content: Content{Status: "Draft", Author: "Bob"}},
+		{ID: 3, Content: Content{Status: "accepted", Author: "Bob, Alice"}},
+	}
+
+	tests := map[string]struct {
+		status string
+		author string
+		ids    []int
+	}{
+		"no_filter": {ids: []int{1, 2, 3}},
+		"status":    {status: "ACCEPTED", ids: []int{1, 3}},
+		"author":    {author: "alice", ids: []int{1, 3}},
+		"both":      {status: "draft", author: "bob", ids: []int{2}},
+		"none":      {status: "Rejected", ids: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var ids []int
+			for _, a := range Filter(adrs, test.status, test.author) {
+				ids = append(ids, a.ID)
+			}
+			assert.Equal(t, test.ids, ids, "")
+		})
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	statusPath := "tests/status/adr/"
+	err := createTestFolder(statusPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(
+		statusPath+"1-Status.md",
+		[]byte("# Status\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Draft | Author | 2025-01-05 | N/A | v0.0.1 |\n\n## Decision Outcome\n"),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// run in order as each step depends on the previous status
+	tests := []struct {
+		name     string
+		id       int
+		status   string
+		force    bool
+		expected string
+		err      bool
+	}{
+		{name: "not_allowed", id: 1, status: "Accepted", expected: "Draft", err: true},
+		{name: "allowed", id: 1, status: "proposed", expected: "Proposed"},
+		{name: "unknown", id: 1, status: "Done", expected: "Proposed", err: true},
+		{name: "force", id: 1, status: "Done", force: true, expected: "Done"},
+		{name: "missing", id: 2, status: "Proposed", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", statusPath)
+			a := NewADR()
+			_, err := a.SetStatus(test.id, test.status, test.force)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+			}
+
+			if test.expected != "" {
+				actual, err := a.Load(test.id)
+				assert.Nil(t, err, "")
+				assert.Equal(t, test.expected, actual.Content.Status, "")
+			}
+		})
+	}
+
+	// a status change is an update to the ADR
+	today := time.Now().Format(time.DateOnly)
+	b, err := os.ReadFile(statusPath + "1-Status.md")
+	assert.Nil(t, err, "")
+	assert.Equal(
+		t,
+		"---\nid: 1\ntitle: Status\nstatus: Done\nauthors:\n  - Author\ndate: \"2025-01-05\"\nupdated: \""+today+"\"\nversion: v0.0.1\n---\n\n# Status\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Done | Author | 2025-01-05 | "+today+" | v0.0.1 |\n\n## Decision Outcome\n",
+		string(b),
+		"",
+	)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Field types, values are stored as a string unless the type says otherwise.
+const (
+	FieldString = "string"
+	FieldInt    = "int"
+	FieldBool   = "bool"
+	FieldList   = "list"
+	FieldDate   = "date"
+)
+
+// fieldTypes are the types a Field can have.
+var fieldTypes = []string{FieldString, FieldInt, FieldBool, FieldList, FieldDate}
+
+// builtinFields are the front matter keys rex manages itself, they can't be
+// used as the name of a Field.
+var builtinFields = frontMatterKeys()
+
+// frontMatterKeys returns the keys of the frontMatter fields from their
+// yaml tags, leaving out the inlined Fields.
+func frontMatterKeys() []string {
+	t := reflect.TypeFor[frontMatter]()
+
+	var keys []string
+	for i := range t.NumField() {
+		name, opts, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || strings.Contains(opts, "inline") {
+			continue
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+// Field is an extra value stored in the front matter of new ADR's. Fields
+// are configured under "adr.fields" in .rex.yaml.
+//
+// Default is used when no value is given, lists are comma separated.
+// Prompt is the label used when prompting for the value, defaulting to the
+// Name. Templates limits the field to ADR's created with the named
+// templates, empty applies it to every ADR.
+type Field struct {
+	Name      string   `mapstructure:"name"      yaml:"name"`
+	Type      string   `mapstructure:"type"      yaml:"type,omitempty"`
+	Default   string   `mapstructure:"default"   yaml:"default,omitempty"`
+	Required  bool     `mapstructure:"required"  yaml:"required,omitempty"`
+	Prompt    string   `mapstructure:"prompt"    yaml:"prompt,omitempty"`
+	Templates []string `mapstructure:"templates" yaml:"templates,omitempty"`
+}
+
+// NewFields reads the fields under "adr.fields". Returns nil if none are
+// configured.
+func NewFields() ([]Field, error) {
+	if !viper.IsSet("adr.fields") {
+		return nil, nil
+	}
+
+	var fields []Field
+	err := viper.UnmarshalKey("adr.fields", &fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid adr.fields: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for i := range fields {
+		f := &fields[i]
+		if f.Type == "" {
+			f.Type = FieldString
+		}
+
+		switch {
+		case f.Name == "":
+			return nil, fmt.Errorf("invalid adr.fields: field %d has no name", i+1)
+		case slices.Contains(builtinFields, f.Name):
+			return nil, fmt.Errorf("invalid adr.fields: %q is a built in ADR field", f.Name)
+		case seen[f.Name]:
+			return nil, fmt.Errorf("invalid adr.fields: %q is set more than once", f.Name)
+		case !slices.Contains(fieldTypes, f.Type):
+			return nil, fmt.Errorf(
+				"invalid adr.fields: %q has unknown type %q, must be one of: %s",
+				f.Name,
+				f.Type,
+				strings.Join(fieldTypes, ", "),
+			)
+		}
+		seen[f.Name] = true
+
+		if f.Default != "" {
+			if _, err := f.Parse(f.Default); err != nil {
+				return nil, fmt.Errorf("invalid adr.fields: default: %w", err)
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+// FieldsFor returns the fields that apply to ADR's created with template.
+func FieldsFor(fields []Field, template string) []Field {
+	var applies []Field
+	for _, f := range fields {
+		if len(f.Templates) == 0 || slices.Contains(f.Templates, template) ||
+			(template == "" && slices.Contains(f.Templates, "default")) {
+			applies = append(applies, f)
+		}
+	}
+	return applies
+}
+
+// Label returns the Prompt for the field, or its Name if it isn't set.
+func (f Field) Label() string {
+	if f.Prompt != "" {
+		return f.Prompt
+	}
+	return f.Name
+}
+
+// Parse converts value to the type of the field.
+func (f Field) Parse(value string) (any, error) {
+	value = strings.TrimSpace(value)
+
+	switch f.Type {
+	case FieldInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number, got %q", f.Name, value)
+		}
+		return n, nil
+	case FieldBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false, got %q", f.Name, value)
+		}
+		return b, nil
+	case FieldList:
+		return splitList(value), nil
+	case FieldDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return nil, fmt.Errorf("%s must be a date as YYYY-MM-DD, got %q", f.Name, value)
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// fieldValues checks values against fields and returns them converted to
+// their types. Missing values are set to the field default, string values
+// are parsed with Field.Parse and other values are kept as they are.
+//
+// Returns an error for values without a field and required fields without a
+// value.
+func fieldValues(fields []Field, values map[string]any) (map[string]any, error) {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		if !slices.Contains(names, k) {
+			if len(names) == 0 {
+				return nil, fmt.Errorf("unknown field %q, no fields are configured under adr.fields", k)
+			}
+			return nil, fmt.Errorf(
+				"unknown field %q, must be one of: %s",
+				k,
+				strings.Join(names, ", "),
+			)
+		}
+	}
+
+	result := map[string]any{}
+	for _, f := range fields {
+		v, ok := values[f.Name]
+		if s, isString := v.(string); !ok || (isString && strings.TrimSpace(s) == "") {
+			ok = f.Default != ""
+			v = f.Default
+		}
+		if !ok {
+			if f.Required {
+				return nil, fmt.Errorf("field %q is required", f.Name)
+			}
+			continue
+		}
+
+		if s, isString := v.(string); isString {
+			var err error
+			v, err = f.Parse(s)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result[f.Name] = v
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
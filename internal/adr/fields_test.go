@@ -0,0 +1,263 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFields(t *testing.T) {
+	tests := map[string]struct {
+		config   any
+		expected []Field
+		err      bool
+	}{
+		"not_set": {
+			config:   nil,
+			expected: nil,
+		},
+		"configured": {
+			config: []any{
+				map[string]any{"name": "jira", "required": true, "prompt": "Jira ticket"},
+				map[string]any{"name": "cost_impact", "type": "int", "default": 0},
+				map[string]any{"name": "consulted", "type": "list", "templates": []any{"madr"}},
+			},
+			expected: []Field{
+				{Name: "jira", Type: FieldString, Required: true, Prompt: "Jira ticket"},
+				{Name: "cost_impact", Type: FieldInt, Default: "0"},
+				{Name: "consulted", Type: FieldList, Templates: []string{"madr"}},
+			},
+		},
+		"no_name": {
+			config: []any{map[string]any{"type": "int"}},
+			err:    true,
+		},
+		"builtin": {
+			config: []any{map[string]any{"name": "deciders"}},
+			err:    true,
+		},
+		"duplicate": {
+			config: []any{map[string]any{"name": "jira"}, map[string]any{"name": "jira"}},
+			err:    true,
+		},
+		"unknown_type": {
+			config: []any{map[string]any{"name": "jira", "type": "ticket"}},
+			err:    true,
+		},
+		"invalid_default": {
+			config: []any{map[string]any{"name": "cost_impact", "type": "int", "default": "high"}},
+			err:    true,
+		},
+		"invalid": {
+			config: "jira",
+			err:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.fields", test.config)
+			defer viper.Set("adr.fields", nil)
+
+			actual, err := NewFields()
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, actual, "")
+		})
+	}
+}
+
+func TestNewFieldsBuiltin(t *testing.T) {
+	keys := []string{
+		"id", "title", "status", "author", "authors", "deciders", "date", "updated",
+		"tags", "version", "supersedes", "superseded_by", "amends", "amended_by", "relates_to",
+	}
+	assert.ElementsMatch(t, keys, builtinFields, "")
+
+	for _, key := range keys {
+		t.Run(key, func(t *testing.T) {
+			viper.Set("adr.fields", []any{map[string]any{"name": key}})
+			defer viper.Set("adr.fields", nil)
+
+			_, err := NewFields()
+			assert.EqualError(t, err, fmt.Sprintf("invalid adr.fields: %q is a built in ADR field", key))
+		})
+	}
+}
+
+func TestFieldParse(t *testing.T) {
+	tests := map[string]struct {
+		field    Field
+		value    string
+		expected any
+		err      bool
+	}{
+		"string":       {field: Field{Name: "jira", Type: FieldString}, value: " PLAT-1 ", expected: "PLAT-1"},
+		"int":          {field: Field{Name: "cost", Type: FieldInt}, value: "3", expected: 3},
+		"int_invalid":  {field: Field{Name: "cost", Type: FieldInt}, value: "high", err: true},
+		"bool":         {field: Field{Name: "breaking", Type: FieldBool}, value: "true", expected: true},
+		"bool_invalid": {field: Field{Name: "breaking", Type: FieldBool}, value: "maybe", err: true},
+		"list":         {field: Field{Name: "consulted", Type: FieldList}, value: "Ops, Security,", expected: []string{"Ops", "Security"}},
+		"date":         {field: Field{Name: "review_by", Type: FieldDate}, value: "2025-06-01", expected: "2025-06-01"},
+		"date_invalid": {field: Field{Name: "review_by", Type: FieldDate}, value: "June", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := test.field.Parse(test.value)
+			if test.err {
+				assert.Error(t, err, "")
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, actual, "")
+		})
+	}
+}
+
+func TestFieldsFor(t *testing.T) {
+	fields := []Field{
+		{Name: "jira"},
+		{Name: "threat_model", Templates: []string{"security-review"}},
+		{Name: "owner", Templates: []string{"default"}},
+	}
+
+	names := func(fields []Field) []string {
+		var n []string
+		for _, f := range fields {
+			n = append(n, f.Name)
+		}
+		return n
+	}
+
+	assert.Equal(t, []string{"jira", "owner"}, names(FieldsFor(fields, "")), "")
+	assert.Equal(t, []string{"jira", "threat_model"}, names(FieldsFor(fields, "security-review")), "")
+	assert.Equal(t, []string{"jira"}, names(FieldsFor(fields, "madr")), "")
+}
+
+func TestFieldValues(t *testing.T) {
+	fields := []Field{
+		{Name: "jira", Type: FieldString, Required: true},
+		{Name: "cost_impact", Type: FieldInt, Default: "0"},
+		{Name: "consulted", Type: FieldList},
+	}
+
+	tests := map[string]struct {
+		fields   []Field
+		values   map[string]any
+		expected map[string]any
+		err      string
+	}{
+		"set": {
+			fields:   fields,
+			values:   map[string]any{"jira": "PLAT-1", "cost_impact": "3", "consulted": "Ops, Security"},
+			expected: map[string]any{"jira": "PLAT-1", "cost_impact": 3, "consulted": []string{"Ops", "Security"}},
+		},
+		"defaults": {
+			fields:   fields,
+			values:   map[string]any{"jira": "PLAT-1", "cost_impact": ""},
+			expected: map[string]any{"jira": "PLAT-1", "cost_impact": 0},
+		},
+		"typed": {
+			fields:   fields,
+			values:   map[string]any{"jira": "PLAT-1", "cost_impact": 5},
+			expected: map[string]any{"jira": "PLAT-1", "cost_impact": 5},
+		},
+		"no_fields": {},
+		"required": {
+			fields: fields,
+			values: map[string]any{"cost_impact": "3"},
+			err:    "field \"jira\" is required",
+		},
+		"invalid": {
+			fields: fields,
+			values: map[string]any{"jira": "PLAT-1", "cost_impact": "high"},
+			err:    "cost_impact must be a number, got \"high\"",
+		},
+		"unknown": {
+			fields: fields,
+			values: map[string]any{"jira": "PLAT-1", "owner": "me"},
+			err:    "unknown field \"owner\", must be one of: jira, cost_impact, consulted",
+		},
+		"unknown_no_fields": {
+			values: map[string]any{"owner": "me"},
+			err:    "unknown field \"owner\", no fields are configured under adr.fields",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := fieldValues(test.fields, test.values)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, actual, "")
+		})
+	}
+}
+
+func TestCreateFields(t *testing.T) {
+	path := "tests/fields/"
+	assert.NoError(t, os.MkdirAll(path, 0o755))
+	defer os.RemoveAll(path)
+
+	viper.Set("adr.path", path)
+	defer viper.Set("adr.path", defaultAdrPath)
+	viper.Set("adr.fields", []any{
+		map[string]any{"name": "jira", "required": true},
+		map[string]any{"name": "review_by", "type": "date", "default": "2025-06-01"},
+	})
+	defer viper.Set("adr.fields", nil)
+
+	_, _, err := NewADR().Create(&Content{Title: "Use gRPC"})
+	assert.EqualError(t, err, "field \"jira\" is required")
+
+	a, _, err := NewADR().Create(&Content{
+		Title:  "Use gRPC",
+		Fields: map[string]any{"jira": "PLAT-1"},
+	})
+	assert.Nil(t, err, "")
+	assert.Equal(t, map[string]any{"jira": "PLAT-1", "review_by": "2025-06-01"}, a.Content.Fields, "")
+
+	// fields are kept in the front matter and read back as they were written
+	doc, err := a.Document()
+	assert.Nil(t, err, "")
+	file := path + "1-use-grpc.md"
+	assert.NoError(t, os.WriteFile(file, []byte(doc), 0o644))
+
+	parsed, err := Parse(file)
+	assert.Nil(t, err, "")
+	assert.Equal(t, a.Content.Fields, parsed.Content.Fields, "")
+
+	idx := &Index{DocPath: path}
+	assert.Equal(t, a.Content.Fields, idx.Process("1-use-grpc.md").Fields, "")
+}
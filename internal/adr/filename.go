@@ -0,0 +1,169 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSlugLength is the longest slug created from a title.
+const maxSlugLength = 60
+
+// adrFileName matches the names of ADR files, an id followed by a dash
+// and the rest of the name, e.g. "1-use-go.md" or "0001-use-go.md".
+var adrFileName = regexp.MustCompile(`^(\d+)-.+\.md$`)
+
+// transliterations are letters that don't decompose into a base letter and
+// accents.
+var transliterations = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "Æ", "ae", "ø", "o", "Ø", "o",
+	"œ", "oe", "Œ", "oe", "ł", "l", "Ł", "l", "đ", "d", "Đ", "d",
+	"þ", "th", "Þ", "th", "ð", "d", "Ð", "d",
+)
+
+// fileNameData is the data passed to the "adr.filename_pattern" template.
+type fileNameData struct {
+	ID    int
+	Slug  string
+	Title string
+}
+
+// fileID returns the id of the ADR file with the given name. Returns false
+// if name is not an ADR file name.
+func fileID(name string) (int, bool) {
+	m := adrFileName.FindStringSubmatch(name)
+	if m == nil {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// Slug returns title as a lowercase ASCII string safe to use in a file
+// name, with accents removed and everything other than letters and digits
+// collapsed to a single dash. Slugs are cut to at most 60 characters on a
+// word boundary where possible.
+//
+// Examples:
+//   - "Use Postgres/Redis: v2?" = "use-postgres-redis-v2"
+//   - "Café Straße" = "cafe-strasse"
+func Slug(title string) string {
+	t := transform.Chain(
+		norm.NFD,
+		runes.Remove(runes.In(unicode.Mn)),
+		norm.NFC,
+	)
+	ascii, _, err := transform.String(t, transliterations.Replace(title))
+	if err != nil {
+		ascii = title
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(ascii) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndex(slug, "-"); i > 0 {
+			slug = slug[:i]
+		}
+	}
+
+	return slug
+}
+
+// FormatID returns id padded with zeros to the configured IDWidth.
+func (a *ADRConfig) FormatID(id int) string {
+	return fmt.Sprintf("%0*d", a.IDWidth, id)
+}
+
+// FileName returns the name of the file for an ADR with the given id and
+// title, created from the FilenamePattern. Without a pattern the name is
+// the id and slug of the title, e.g. "1-use-go.md" or "0001-use-go.md" with
+// an IDWidth of 4.
+//
+// Returns error if the pattern is invalid or doesn't create an ADR file
+// name for the id.
+func (a *ADRConfig) FileName(id int, title string) (string, error) {
+	return a.fileName(id, Slug(title), title)
+}
+
+// fileName returns the name of the file for an ADR with the given id,
+// slug and title.
+func (a *ADRConfig) fileName(id int, slug, title string) (string, error) {
+	if slug == "" {
+		slug = "adr"
+	}
+
+	if a.FilenamePattern == "" {
+		return fmt.Sprintf("%s-%s.md", a.FormatID(id), slug), nil
+	}
+
+	tmpl, err := template.New("filename").Parse(a.FilenamePattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid adr.filename_pattern: %w", err)
+	}
+
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, fileNameData{ID: id, Slug: slug, Title: title})
+	if err != nil {
+		return "", fmt.Errorf("invalid adr.filename_pattern: %w", err)
+	}
+
+	name := b.String()
+	if n, ok := fileID(name); !ok || n != id || filepath.Base(name) != name {
+		return "", fmt.Errorf(
+			"adr.filename_pattern must create names starting with the id like \"%d-title.md\", got %q",
+			id,
+			name,
+		)
+	}
+
+	return name, nil
+}
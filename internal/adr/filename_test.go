@@ -0,0 +1,135 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileID(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		id   int
+		ok   bool
+	}{
+		"adr":         {name: "1-use-go.md", id: 1, ok: true},
+		"padded":      {name: "0012-use-go.md", id: 12, ok: true},
+		"index":       {name: "README.md", id: 0, ok: false},
+		"no_title":    {name: "1.md", id: 0, ok: false},
+		"not_md":      {name: "1-use-go.txt", id: 0, ok: false},
+		"hidden":      {name: ".gitkeep", id: 0, ok: false},
+		"no_id":       {name: "use-go.md", id: 0, ok: false},
+		"negative_id": {name: "-1-use-go.md", id: 0, ok: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, ok := fileID(tc.name)
+			assert.Equal(t, tc.id, id)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]struct {
+		title    string
+		expected string
+	}{
+		"simple":      {title: "Use Go", expected: "use-go"},
+		"punctuation": {title: "Use Postgres/Redis: v2?", expected: "use-postgres-redis-v2"},
+		"whitespace":  {title: "  Use \t Go \n", expected: "use-go"},
+		"accents":     {title: "Café Straße Ørsted", expected: "cafe-strasse-orsted"},
+		"non_latin":   {title: "使用 Go", expected: "go"},
+		"empty":       {title: "?!", expected: ""},
+		"too_long": {
+			title:    "Use a very long title for this decision record that goes on and on",
+			expected: "use-a-very-long-title-for-this-decision-record-that-goes-on",
+		},
+		"too_long_word": {
+			title:    strings.Repeat("a", 70),
+			expected: strings.Repeat("a", 60),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Slug(tc.title))
+		})
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := map[string]struct {
+		width    int
+		pattern  string
+		id       int
+		title    string
+		expected string
+		err      bool
+	}{
+		"no_padding": {id: 7, title: "Use Go", expected: "7-use-go.md"},
+		"padding":    {width: 4, id: 7, title: "Use Go", expected: "0007-use-go.md"},
+		"too_long":   {width: 2, id: 123, title: "Use Go", expected: "123-use-go.md"},
+		"no_slug":    {id: 7, title: "?", expected: "7-adr.md"},
+		"pattern": {
+			pattern:  `{{ printf "%04d" .ID }}-{{ .Slug }}.md`,
+			id:       7,
+			title:    "Use Go",
+			expected: "0007-use-go.md",
+		},
+		"pattern_without_id": {
+			pattern: `{{ .Slug }}.md`,
+			id:      7,
+			title:   "Use Go",
+			err:     true,
+		},
+		"pattern_with_path": {
+			pattern: `{{ .ID }}-adrs/{{ .Slug }}.md`,
+			id:      7,
+			title:   "Use Go",
+			err:     true,
+		},
+		"invalid_pattern": {
+			pattern: `{{ .ID }`,
+			id:      7,
+			title:   "Use Go",
+			err:     true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &ADRConfig{IDWidth: tc.width, FilenamePattern: tc.pattern}
+			got, err := c.FileName(tc.id, tc.title)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
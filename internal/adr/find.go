@@ -0,0 +1,143 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/donaldgifford/rex/internal/markdown"
+)
+
+// Find returns the ADR ref points to. ref is either an id, IE: "3", or the
+// slug of the ADR's file name or title, IE: "use-postgres".
+//
+// Returns error if no ADR matches or more than one ADR does.
+func (adr *ADR) Find(ref string) (*ADR, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("no ADR id or slug given")
+	}
+
+	// ADR's that can't be parsed can't be matched, so they are left out
+	adrs, err := adr.List()
+	var skipped *SkippedError
+	if err != nil && !errors.As(err, &skipped) {
+		return nil, err
+	}
+
+	var found []*ADR
+	if id, err := strconv.Atoi(ref); err == nil {
+		for _, a := range adrs {
+			if a.ID == id {
+				found = append(found, a)
+			}
+		}
+	} else {
+		slug := Slug(strings.TrimSuffix(ref, ".md"))
+		for _, a := range adrs {
+			if matchesSlug(a, slug) {
+				found = append(found, a)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no ADR found matching %q in %s", ref, adr.Config.Path)
+	case 1:
+		return found[0], nil
+	}
+
+	files := make([]string, 0, len(found))
+	for _, a := range found {
+		files = append(files, filepath.Base(a.File))
+	}
+	return nil, fmt.Errorf(
+		"%q matches more than one ADR: %s",
+		ref,
+		strings.Join(files, ", "),
+	)
+}
+
+// matchesSlug reports if slug is the slug of the ADR's file name, with or
+// without its id, or of its title.
+func matchesSlug(a *ADR, slug string) bool {
+	name := strings.TrimSuffix(filepath.Base(a.File), ".md")
+	_, rest := nameParts(filepath.Base(a.File))
+
+	return slug == Slug(name) ||
+		slug == Slug(rest) ||
+		slug == Slug(a.Content.Title)
+}
+
+// Prose returns the Body without the title heading and the metadata table
+// beneath it, for showing an ADR where its metadata is displayed separately.
+func (adr *ADR) Prose() string {
+	lines := strings.Split(adr.Body, "\n")
+
+	for i, l := range lines {
+		if strings.HasPrefix(l, "# ") {
+			lines = append(lines[:i:i], lines[i+1:]...)
+			break
+		}
+	}
+
+	if table, err := parseMetadataTable(lines); err == nil &&
+		!slices.ContainsFunc(lines[:table.row], isHeading) {
+		start := table.row - 2
+		end := table.row + 1
+		for end < len(lines) && markdown.IsTableRow(lines[end]) {
+			end++
+		}
+		lines = append(lines[:start:start], lines[end:]...)
+	}
+
+	return strings.TrimLeft(strings.Join(lines, "\n"), "\n")
+}
+
+// ProseWithoutRelations returns the Prose without its Related ADRs
+// section, for showing an ADR where its relations are listed separately.
+func (adr *ADR) ProseWithoutRelations() string {
+	lines := strings.Split(adr.Prose(), "\n")
+	start := findSection(lines, relatedHeading)
+	if start == -1 {
+		return adr.Prose()
+	}
+
+	end := start + 1
+	for end < len(lines) && !isHeading(lines[end]) {
+		end++
+	}
+	lines = append(lines[:start:start], lines[end:]...)
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// isHeading reports if the line is a markdown heading.
+func isHeading(line string) bool {
+	return strings.HasPrefix(line, "#")
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	findPath := "tests/find/adr/"
+	err := createTestFolder(findPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"1-use-postgres.md": "# Use Postgres\n",
+		"2-cache.md":        "# Use Redis for caching\n",
+		"3-queue.md":        "# Use a queue\n",
+		"3-events.md":       "# Publish events\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(findPath+name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Set("adr.path", findPath)
+	a := NewADR()
+
+	tests := map[string]struct {
+		ref  string
+		file string
+		err  bool
+	}{
+		"id":          {ref: "1", file: findPath + "1-use-postgres.md"},
+		"slug":        {ref: "use-postgres", file: findPath + "1-use-postgres.md"},
+		"file_name":   {ref: "2-cache.md", file: findPath + "2-cache.md"},
+		"title":       {ref: "Use Redis for Caching", file: findPath + "2-cache.md"},
+		"title_slug":  {ref: "publish-events", file: findPath + "3-events.md"},
+		"duplicateid": {ref: "3", err: true},
+		"missing_id":  {ref: "9", err: true},
+		"missing":     {ref: "use-mysql", err: true},
+		"empty":       {ref: " ", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			found, err := a.Find(test.ref)
+			if test.err {
+				assert.Error(t, err, "")
+				return
+			}
+			assert.NoError(t, err, "")
+			assert.Equal(t, test.file, found.File, "")
+		})
+	}
+}
+
+func TestProse(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"title_and_table": {
+			body:     "# Title\n\n| Status | Author |\n| ------ | ------ |\n| Draft | Alice |\n\n## Context\n\nText\n",
+			expected: "## Context\n\nText\n",
+		},
+		"no_table": {
+			body:     "# Title\n\n## Context\n\n| Status | Note |\n| ------ | ---- |\n| Draft | kept |\n",
+			expected: "## Context\n\n| Status | Note |\n| ------ | ---- |\n| Draft | kept |\n",
+		},
+		"no_title": {
+			body:     "Text\n",
+			expected: "Text\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &ADR{Body: test.body}
+			assert.Equal(t, test.expected, a.Prose(), "")
+		})
+	}
+}
+
+func TestProseWithoutRelations(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"relations_last": {
+			body:     "# Title\n\n## Context\n\nText\n\n## Related ADRs\n\n- Supersedes [ADR 1: Old](1-Old.md)\n",
+			expected: "## Context\n\nText\n",
+		},
+		"relations_before_section": {
+			body:     "# Title\n\n## Related ADRs\n\n- Relates to [ADR 2: Other](2-Other.md)\n\n## Revision History\n\n- v0.0.1\n",
+			expected: "## Revision History\n\n- v0.0.1\n",
+		},
+		"no_relations": {
+			body:     "# Title\n\n## Context\n\nText\n",
+			expected: "## Context\n\nText\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &ADR{Body: test.body}
+			assert.Equal(t, test.expected, a.ProseWithoutRelations(), "")
+		})
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelimiter opens and closes the YAML front matter of an ADR.
+const frontMatterDelimiter = "---"
+
+// frontMatter is the YAML metadata at the top of an ADR. It is the
+// canonical source of an ADR's metadata, the metadata table in the body is
+// rendered from it.
+//
+// Fields holds every other key, the values of configured fields as well as
+// keys added by hand, so they are kept when the ADR is rewritten.
+type frontMatter struct {
+	ID           int            `yaml:"id"`
+	Title        string         `yaml:"title"`
+	Status       string         `yaml:"status"`
+	Author       string         `yaml:"author,omitempty"`
+	Authors      []string       `yaml:"authors,omitempty"`
+	Deciders     []string       `yaml:"deciders,omitempty"`
+	Date         string         `yaml:"date,omitempty"`
+	Updated      string         `yaml:"updated,omitempty"`
+	Tags         []string       `yaml:"tags,omitempty"`
+	Version      string         `yaml:"version,omitempty"`
+	Supersedes   []int          `yaml:"supersedes,omitempty"`
+	SupersededBy []int          `yaml:"superseded_by,omitempty"`
+	Amends       []int          `yaml:"amends,omitempty"`
+	AmendedBy    []int          `yaml:"amended_by,omitempty"`
+	RelatesTo    []int          `yaml:"relates_to,omitempty"`
+	Fields       map[string]any `yaml:",inline"`
+}
+
+// newFrontMatter creates the front matter for an ADR from its Content.
+func newFrontMatter(a *ADR) *frontMatter {
+	fm := &frontMatter{
+		ID:       a.ID,
+		Title:    a.Content.Title,
+		Status:   a.Content.Status,
+		Authors:  splitList(a.Content.Author),
+		Deciders: a.Content.Deciders,
+		Date:     a.Content.Date,
+		Updated:  a.Content.Updated,
+		Tags:     a.Content.Tags,
+		Version:  a.Content.Version,
+		Fields:   a.Content.Fields,
+	}
+
+	relations := fm.relations()
+	for _, r := range a.Content.Relations {
+		if ids, ok := relations[r.Type]; ok {
+			*ids = append(*ids, r.ID)
+		}
+	}
+
+	return fm
+}
+
+// relations maps each relation type to the front matter field holding it.
+func (fm *frontMatter) relations() map[string]*[]int {
+	return map[string]*[]int{
+		Supersedes:   &fm.Supersedes,
+		SupersededBy: &fm.SupersededBy,
+		Amends:       &fm.Amends,
+		AmendedBy:    &fm.AmendedBy,
+		RelatesTo:    &fm.RelatesTo,
+	}
+}
+
+// apply overrides the ADR with any values set in the front matter.
+func (fm *frontMatter) apply(a *ADR) {
+	if fm.ID != 0 {
+		a.ID = fm.ID
+	}
+
+	authors := fm.Authors
+	if fm.Author != "" {
+		authors = append([]string{fm.Author}, authors...)
+	}
+
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&a.Content.Title, fm.Title)
+	set(&a.Content.Status, fm.Status)
+	set(&a.Content.Author, strings.Join(authors, ", "))
+	set(&a.Content.Date, fm.Date)
+	set(&a.Content.Updated, fm.Updated)
+	set(&a.Content.Version, fm.Version)
+
+	if len(fm.Tags) > 0 {
+		a.Content.Tags = fm.Tags
+	}
+	if len(fm.Deciders) > 0 {
+		a.Content.Deciders = fm.Deciders
+	}
+	if len(fm.Fields) > 0 {
+		a.Content.Fields = map[string]any{}
+		for k, v := range fm.Fields {
+			a.Content.Fields[k] = fieldDate(v)
+		}
+	}
+
+	// relations listed only in the front matter don't have a title or file
+	for t, ids := range fm.relations() {
+		for _, id := range *ids {
+			if !hasRelation(a.Content.Relations, t, id) {
+				a.Content.Relations = append(
+					a.Content.Relations,
+					Relation{Type: t, ID: id},
+				)
+			}
+		}
+	}
+}
+
+// String renders the front matter as YAML without the delimiters.
+func (fm *frontMatter) String() (string, error) {
+	var b bytes.Buffer
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+
+	err := e.Encode(fm)
+	if err != nil {
+		return "", err
+	}
+
+	err = e.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// FrontMatter returns the YAML front matter for the ADR without the
+// delimiters, for use in templates:
+//
+//	---
+//	{{ .FrontMatter }}---
+func (adr *ADR) FrontMatter() (string, error) {
+	return newFrontMatter(adr).String()
+}
+
+// Document renders the ADR file with front matter created from its Content
+// followed by the Body. The metadata table in the Body, if there is one, is
+// updated to match the front matter.
+func (adr *ADR) Document() (string, error) {
+	fm, err := adr.FrontMatter()
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(adr.Body, "\n")
+	if table, err := parseMetadataTable(lines); err == nil {
+		updated := adr.Content.Updated
+		if updated == "" {
+			updated = "N/A"
+		}
+
+		table.Set(headerStatus, adr.Content.Status)
+		table.Set(headerAuthor, adr.Content.Author)
+		table.Set(headerCreated, adr.Content.Date)
+		table.Set(headerLastUpdate, updated)
+		table.Set(headerVersion, adr.Content.Version)
+		lines[table.row] = table.String()
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s%s\n\n%s",
+		frontMatterDelimiter,
+		fm,
+		frontMatterDelimiter,
+		strings.Join(lines, "\n"),
+	), nil
+}
+
+// splitFrontMatter separates the YAML front matter from the markdown body.
+// Documents without front matter return an empty frontMatter and the full
+// document as the body.
+func splitFrontMatter(doc string) (*frontMatter, string, error) {
+	fm := &frontMatter{}
+
+	rest, ok := strings.CutPrefix(doc, frontMatterDelimiter+"\n")
+	if !ok {
+		return fm, doc, nil
+	}
+
+	var raw string
+	lines := strings.SplitAfter(rest, "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) == frontMatterDelimiter {
+			raw = strings.Join(lines[:i], "")
+			rest = strings.Join(lines[i+1:], "")
+			break
+		}
+		if i == len(lines)-1 {
+			return nil, "", fmt.Errorf("front matter is not closed")
+		}
+	}
+
+	err := yaml.Unmarshal([]byte(raw), fm)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	return fm, strings.TrimLeft(rest, "\n"), nil
+}
+
+// fieldDate returns v as a date string if YAML decoded it as a time, so
+// dates keep the format they were written with. Other values are returned
+// unchanged.
+func fieldDate(v any) any {
+	t, ok := v.(time.Time)
+	if !ok {
+		return v
+	}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// splitList splits a comma separated list, dropping empty values.
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
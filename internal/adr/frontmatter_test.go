@@ -0,0 +1,166 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontMatter(t *testing.T) {
+	tests := map[string]struct {
+		adr      *ADR
+		expected string
+	}{
+		"minimal": {
+			adr: &ADR{
+				ID:      1,
+				Content: Content{Title: "Use Go", Status: "Draft"},
+			},
+			expected: "id: 1\ntitle: Use Go\nstatus: Draft\n",
+		},
+		"full": {
+			adr: &ADR{
+				ID: 2,
+				Content: Content{
+					Title:    "Use Postgres",
+					Status:   "Accepted",
+					Author:   "Jane Doe, John Doe",
+					Deciders: []string{"Team"},
+					Date:     "2025-01-05",
+					Updated:  "2025-02-01",
+					Tags:     []string{"db"},
+					Version:  "v0.0.2",
+					Relations: []Relation{
+						{Type: Supersedes, ID: 1},
+						{Type: RelatesTo, ID: 3},
+					},
+				},
+			},
+			expected: "id: 2\ntitle: Use Postgres\nstatus: Accepted\nauthors:\n  - Jane Doe\n  - John Doe\ndeciders:\n  - Team\ndate: \"2025-01-05\"\nupdated: \"2025-02-01\"\ntags:\n  - db\nversion: v0.0.2\nsupersedes:\n  - 1\nrelates_to:\n  - 3\n",
+		},
+		"fields": {
+			adr: &ADR{
+				ID: 3,
+				Content: Content{
+					Title:  "Use gRPC",
+					Status: "Draft",
+					Fields: map[string]any{"jira": "PLAT-1", "cost_impact": 3, "review_by": "2025-06-01"},
+				},
+			},
+			expected: "id: 3\ntitle: Use gRPC\nstatus: Draft\ncost_impact: 3\njira: PLAT-1\nreview_by: \"2025-06-01\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.adr.FrontMatter()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestDocument(t *testing.T) {
+	a := &ADR{
+		ID: 1,
+		Content: Content{
+			Title:   "Use Go",
+			Status:  "Accepted",
+			Author:  "Author",
+			Date:    "2025-01-05",
+			Version: "v0.0.1",
+		},
+		Body: "# Use Go\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Draft | Author | 2025-01-05 | N/A | v0.0.1 |\n",
+	}
+
+	expected := "---\nid: 1\ntitle: Use Go\nstatus: Accepted\nauthors:\n  - Author\ndate: \"2025-01-05\"\nversion: v0.0.1\n---\n\n# Use Go\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Accepted | Author | 2025-01-05 | N/A | v0.0.1 |\n"
+
+	got, err := a.Document()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, got)
+
+	// the document parses back to the same ADR
+	parsed, err := parse("1-Use-Go.md", []byte(got))
+	assert.NoError(t, err)
+	assert.Equal(t, a.Content, parsed.Content)
+	assert.Equal(t, a.ID, parsed.ID)
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := map[string]struct {
+		doc      string
+		expected *frontMatter
+		body     string
+		err      bool
+	}{
+		"none": {
+			doc:      "# Title\n",
+			expected: &frontMatter{},
+			body:     "# Title\n",
+		},
+		"front matter": {
+			doc:      "---\nid: 3\ntitle: Title\nauthor: Jane\namends: [1]\n---\n\n# Title\n",
+			expected: &frontMatter{ID: 3, Title: "Title", Author: "Jane", Amends: []int{1}},
+			body:     "# Title\n",
+		},
+		"not closed": {
+			doc: "---\nid: 3\n# Title\n",
+			err: true,
+		},
+		"invalid": {
+			doc: "---\nid: [\n---\n# Title\n",
+			err: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			fm, body, err := splitFrontMatter(tc.doc)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, fm)
+			assert.Equal(t, tc.body, body)
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected []string
+	}{
+		"empty":  {input: "", expected: nil},
+		"single": {input: "Jane", expected: []string{"Jane"}},
+		"many":   {input: "Jane, John,, ", expected: []string{"Jane", "John"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, splitList(tc.input))
+		})
+	}
+}
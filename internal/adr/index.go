@@ -23,10 +23,9 @@ THE SOFTWARE.
 package adr
 
 import (
-	"fmt"
+	"net/url"
 	"os"
-	"strconv"
-	"strings"
+	"path/filepath"
 
 	"github.com/spf13/viper"
 )
@@ -39,22 +38,45 @@ type IIndex interface {
 }
 
 // Index contains data on an index including its content
+//
+// SortBy and GroupBy set the order of the ADR's in the index and how they
+// are grouped into sections, see SortByID and GroupByStatus.
 type Index struct {
 	DocPath       string
 	IndexFileName string
+	SortBy        string
+	GroupBy       string
 	Content       IndexContent
 }
 
 // IndexContent contains data on the adr's in its index
+//
+// Groups is only set if the index is grouped, Adrs always holds every ADR.
 type IndexContent struct {
-	Title string
-	Adrs  []*IndexAdr
+	Title  string
+	Adrs   []*IndexAdr
+	Groups []*IndexGroup
 }
 
-// IndexAdr is the data used for indexing adrs by title and id
+// IndexAdr is the data used for indexing adrs
+//
+// File is the path of the ADR relative to the index page. Fields holds the
+// extra front matter values of the ADR, see Field.
 type IndexAdr struct {
-	Id    int
-	Title string
+	Id        int
+	Title     string
+	File      string
+	Status    string
+	Date      string
+	Author    string
+	Tags      []string
+	Relations []Relation
+	Fields    map[string]any
+}
+
+// Link returns File escaped for use as a markdown link target.
+func (ia *IndexAdr) Link() string {
+	return (&url.URL{Path: ia.File}).EscapedPath()
 }
 
 // NewIIndex creates a new Index to be used
@@ -63,6 +85,8 @@ func NewIIndex() *Index {
 	return &Index{
 		DocPath:       viper.GetString("adr.path"),
 		IndexFileName: viper.GetString("adr.index_page"),
+		SortBy:        viper.GetString("adr.index_sort"),
+		GroupBy:       viper.GetString("adr.index_group_by"),
 		Content: IndexContent{
 			Title: "ADR Index",
 		},
@@ -77,40 +101,74 @@ func (idx *Index) Execute() *Index {
 }
 
 // ADRs reads the current adrs in the config path and updates the Index
-// with them.
+// with them, sorted and grouped as set in the Index.
 func (idx *Index) ADRs() error {
 	var myAdrs []*IndexAdr
 
 	// read adrs in the configs DocPath
 	entries, err := os.ReadDir(idx.DocPath)
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 
 	// iterate over the adrs and add them to the index
 	for _, e := range entries {
-		if e.Name() != idx.IndexFileName {
+		if e.IsDir() || e.Name() == idx.IndexFileName {
+			continue
+		}
+		if _, ok := fileID(e.Name()); ok {
 			adr := idx.Process(e.Name())
 			myAdrs = append(myAdrs, adr)
-			fmt.Println(e.Name())
 		}
 	}
+
+	workflow, err := NewWorkflow()
+	if err != nil {
+		return err
+	}
+
+	err = sortAdrs(myAdrs, idx.SortBy, workflow)
+	if err != nil {
+		return err
+	}
+
+	groups, err := groupAdrs(myAdrs, idx.GroupBy, workflow)
+	if err != nil {
+		return err
+	}
+
 	idx.Content.Adrs = myAdrs
+	idx.Content.Groups = groups
 	return nil
 }
 
 // Process takes a file name and returns the IndexAdr
 //
+// The ADR file is parsed for its metadata. If the file can't be read the
+// id and title are taken from the file name.
+//
 // Name examples:
-//   - "1-my-adr.md" = IndexAdr{Id: 1, Title: "my adr"}
+//   - "1-my-adr.md" = IndexAdr{Id: 1, Title: "my-adr", File: "1-my-adr.md"}
 func (idx *Index) Process(file string) *IndexAdr {
-	idTitle := strings.SplitN(file, "-", 2)
-	id, _ := strconv.Atoi(idTitle[0])
-	title := strings.TrimSuffix(idTitle[1], ".md")
+	a, err := Parse(filepath.Join(idx.DocPath, file))
+	if err != nil {
+		id, title := nameParts(file)
+		return &IndexAdr{
+			Id:    id,
+			Title: title,
+			File:  file,
+		}
+	}
 
 	return &IndexAdr{
-		Id:    id,
-		Title: title,
+		Id:        a.ID,
+		Title:     a.Content.Title,
+		File:      file,
+		Status:    a.Content.Status,
+		Date:      a.Content.Date,
+		Author:    a.Content.Author,
+		Tags:      a.Content.Tags,
+		Relations: a.Content.Relations,
+		Fields:    a.Content.Fields,
 	}
 }
@@ -0,0 +1,189 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Index sort keys and groupings set by "adr.index_sort" and
+// "adr.index_group_by". A sort key can be prefixed with "-" to sort in
+// descending order, IE: "-date".
+const (
+	SortByID     = "id"
+	SortByDate   = "date"
+	SortByStatus = "status"
+	SortByTitle  = "title"
+
+	GroupByStatus = "status"
+	GroupByTag    = "tag"
+)
+
+// Names of the groups for ADR's without a status or tags.
+const (
+	noStatusGroup = "No Status"
+	untaggedGroup = "Untagged"
+)
+
+// IndexGroup is a section of the index holding the ADR's with the same
+// status or tag.
+type IndexGroup struct {
+	Name string
+	Adrs []*IndexAdr
+}
+
+// sortAdrs orders adrs by the key, ties are ordered by id. Statuses are
+// ordered as they appear in the workflow.
+func sortAdrs(adrs []*IndexAdr, key string, workflow *Workflow) error {
+	field, desc := strings.CutPrefix(key, "-")
+
+	var compare func(a, b *IndexAdr) int
+	switch field {
+	case "", SortByID:
+		compare = func(a, b *IndexAdr) int { return 0 }
+	case SortByDate:
+		compare = func(a, b *IndexAdr) int { return cmp.Compare(a.Date, b.Date) }
+	case SortByStatus:
+		compare = func(a, b *IndexAdr) int {
+			return compareStatus(a.Status, b.Status, workflow)
+		}
+	case SortByTitle:
+		compare = func(a, b *IndexAdr) int {
+			return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+	default:
+		return fmt.Errorf(
+			"unknown index sort %q, must be one of: %s, %s, %s, %s",
+			key,
+			SortByID,
+			SortByDate,
+			SortByStatus,
+			SortByTitle,
+		)
+	}
+
+	slices.SortStableFunc(adrs, func(a, b *IndexAdr) int {
+		c := compare(a, b)
+		if c == 0 {
+			c = cmp.Compare(a.Id, b.Id)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	return nil
+}
+
+// groupAdrs splits adrs into groups by status or tag, keeping the order
+// of adrs in each group. Status groups are ordered as they appear in the
+// workflow and tag groups by name. An ADR with several tags is listed in
+// each of their groups.
+func groupAdrs(adrs []*IndexAdr, by string, workflow *Workflow) ([]*IndexGroup, error) {
+	var keys func(a *IndexAdr) []string
+	var compare func(a, b string) int
+	switch by {
+	case "":
+		return nil, nil
+	case GroupByStatus:
+		keys = func(a *IndexAdr) []string {
+			if a.Status == "" {
+				return []string{noStatusGroup}
+			}
+			return []string{a.Status}
+		}
+		compare = func(a, b string) int {
+			return compareStatus(a, b, workflow)
+		}
+	case GroupByTag:
+		keys = func(a *IndexAdr) []string {
+			if len(a.Tags) == 0 {
+				return []string{untaggedGroup}
+			}
+			return a.Tags
+		}
+		compare = func(a, b string) int {
+			return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+		}
+	default:
+		return nil, fmt.Errorf(
+			"unknown index grouping %q, must be one of: %s, %s",
+			by,
+			GroupByStatus,
+			GroupByTag,
+		)
+	}
+
+	var groups []*IndexGroup
+	for _, a := range adrs {
+		for _, k := range keys(a) {
+			i := slices.IndexFunc(groups, func(g *IndexGroup) bool {
+				return strings.EqualFold(g.Name, k)
+			})
+			if i == -1 {
+				groups = append(groups, &IndexGroup{Name: k})
+				i = len(groups) - 1
+			}
+			groups[i].Adrs = append(groups[i].Adrs, a)
+		}
+	}
+
+	// ADR's without a status or tags are always last
+	slices.SortStableFunc(groups, func(a, b *IndexGroup) int {
+		aNone := a.Name == noStatusGroup || a.Name == untaggedGroup
+		bNone := b.Name == noStatusGroup || b.Name == untaggedGroup
+		switch {
+		case aNone && !bNone:
+			return 1
+		case bNone && !aNone:
+			return -1
+		}
+		return compare(a.Name, b.Name)
+	})
+
+	return groups, nil
+}
+
+// compareStatus orders statuses as they appear in the workflow. Statuses
+// that aren't part of the workflow come after, ordered by name.
+func compareStatus(a, b string, workflow *Workflow) int {
+	statuses := workflow.Statuses()
+	rank := func(s string) int {
+		i := slices.IndexFunc(statuses, func(v string) bool {
+			return strings.EqualFold(v, s)
+		})
+		if i == -1 {
+			return len(statuses)
+		}
+		return i
+	}
+
+	if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testIndexAdrs() []*IndexAdr {
+	return []*IndexAdr{
+		{Id: 10, Title: "Use Redis", Status: "Draft", Date: "2025-03-01", Tags: []string{"cache"}},
+		{Id: 2, Title: "use Go", Status: "Accepted", Date: "2025-01-01", Tags: []string{"lang", "build"}},
+		{Id: 1, Title: "Use Postgres", Status: "Retired", Date: "2025-02-01"},
+		{Id: 3, Title: "Use MySQL", Status: "Accepted", Date: "2025-02-01", Tags: []string{"Build"}},
+		{Id: 4, Title: "Use Rust"},
+	}
+}
+
+func ids(adrs []*IndexAdr) []int {
+	var ids []int
+	for _, a := range adrs {
+		ids = append(ids, a.Id)
+	}
+	return ids
+}
+
+func TestSortAdrs(t *testing.T) {
+	tests := map[string]struct {
+		key      string
+		expected []int
+		err      bool
+	}{
+		"default":     {key: "", expected: []int{1, 2, 3, 4, 10}},
+		"id":          {key: "id", expected: []int{1, 2, 3, 4, 10}},
+		"id_desc":     {key: "-id", expected: []int{10, 4, 3, 2, 1}},
+		"date":        {key: "date", expected: []int{4, 2, 1, 3, 10}},
+		"date_desc":   {key: "-date", expected: []int{10, 3, 1, 2, 4}},
+		"status":      {key: "status", expected: []int{10, 2, 3, 4, 1}},
+		"title":       {key: "title", expected: []int{2, 3, 1, 10, 4}},
+		"unknown_key": {key: "author", err: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			adrs := testIndexAdrs()
+			err := sortAdrs(adrs, tc.key, DefaultWorkflow())
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ids(adrs))
+		})
+	}
+}
+
+func TestGroupAdrs(t *testing.T) {
+	type group struct {
+		name string
+		ids  []int
+	}
+
+	tests := map[string]struct {
+		by       string
+		expected []group
+		err      bool
+	}{
+		"none": {by: "", expected: nil},
+		"status": {
+			by: "status",
+			expected: []group{
+				{name: "Draft", ids: []int{10}},
+				{name: "Accepted", ids: []int{2, 3}},
+				{name: "Retired", ids: []int{1}},
+				{name: "No Status", ids: []int{4}},
+			},
+		},
+		"tag": {
+			by: "tag",
+			expected: []group{
+				{name: "build", ids: []int{2, 3}},
+				{name: "cache", ids: []int{10}},
+				{name: "lang", ids: []int{2}},
+				{name: "Untagged", ids: []int{1, 4}},
+			},
+		},
+		"unknown": {by: "author", err: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			adrs := testIndexAdrs()
+			err := sortAdrs(adrs, "", DefaultWorkflow())
+			assert.NoError(t, err)
+
+			groups, err := groupAdrs(adrs, tc.by, DefaultWorkflow())
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var actual []group
+			for _, g := range groups {
+				actual = append(actual, group{name: g.Name, ids: ids(g.Adrs)})
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
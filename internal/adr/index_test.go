@@ -132,9 +132,11 @@ func TestIndexADRs(t *testing.T) {
 				{
 					Id:    1,
 					Title: "test1",
+					File:  "1-test1.md",
 				}, {
 					Id:    2,
 					Title: "test2",
+					File:  "2-test2.md",
 				},
 			},
 			path:  defaultAdrPath,
@@ -175,3 +177,64 @@ func TestIndexADRs(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexAdrLink(t *testing.T) {
+	tests := map[string]struct {
+		file     string
+		expected string
+	}{
+		"slug":   {file: "1-use-go.md", expected: "1-use-go.md"},
+		"spaces": {file: "1-Use Go.md", expected: "1-Use%20Go.md"},
+		"empty":  {file: "", expected: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ia := &IndexAdr{File: test.file}
+			assert.Equal(t, test.expected, ia.Link(), "")
+		})
+	}
+}
+
+func TestIndexADRsOrder(t *testing.T) {
+	orderPath := "tests/order/adr/"
+	err := createTestFolder(orderPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"10-ten.md", "2-two.md", "1-one.md", "README.md"} {
+		err = createTestADRFile(orderPath + f)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Set("adr.path", orderPath)
+	viper.Set("adr.index_page", "README.md")
+
+	tests := map[string]struct {
+		sort     string
+		expected []int
+		err      bool
+	}{
+		"numeric": {sort: "", expected: []int{1, 2, 10}},
+		"desc":    {sort: "-id", expected: []int{10, 2, 1}},
+		"unknown": {sort: "size", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.index_sort", test.sort)
+			defer viper.Set("adr.index_sort", "")
+
+			i := NewIIndex()
+			err := i.ADRs()
+			if test.err {
+				assert.Error(t, err, "")
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, ids(i.Content.Adrs), "")
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/donaldgifford/rex/internal/markdown"
+)
+
+// Headers used in the metadata table rendered by the default ADR template.
+const (
+	headerStatus     = "Status"
+	headerAuthor     = "Author"
+	headerCreated    = "Created"
+	headerLastUpdate = "Last Update"
+	headerVersion    = "Current Version"
+)
+
+// revisionHeading is the section revisions are recorded under.
+const revisionHeading = "## Revision History"
+
+// errNoMetadataTable is returned when an ADR has no metadata table to update.
+var errNoMetadataTable = errors.New("no metadata table found")
+
+// metadataTable is the Status/Author/Created table found beneath the title
+// of an ADR.
+type metadataTable struct {
+	row     int // line index of the value row
+	headers []string
+	values  []string
+}
+
+// parseMetadataTable finds the first markdown table whose header contains a
+// "Status" column and returns it along with the line of its value row.
+func parseMetadataTable(lines []string) (*metadataTable, error) {
+	for i := 0; i+2 < len(lines); i++ {
+		if !markdown.IsTableRow(lines[i]) || !markdown.IsTableRow(lines[i+2]) {
+			continue
+		}
+
+		headers := markdown.TableCells(lines[i])
+		if !markdown.IsSeparatorRow(lines[i+1]) || indexOf(headers, headerStatus) == -1 {
+			continue
+		}
+
+		values := markdown.TableCells(lines[i+2])
+		for len(values) < len(headers) {
+			values = append(values, "")
+		}
+
+		return &metadataTable{
+			row:     i + 2,
+			headers: headers,
+			values:  values,
+		}, nil
+	}
+
+	return nil, errNoMetadataTable
+}
+
+// Get returns the value in the column with the given header.
+func (mt *metadataTable) Get(header string) string {
+	i := indexOf(mt.headers, header)
+	if i == -1 {
+		return ""
+	}
+	return mt.values[i]
+}
+
+// Set updates the value in the column with the given header. Headers not in
+// the table are ignored.
+func (mt *metadataTable) Set(header, value string) {
+	i := indexOf(mt.headers, header)
+	if i == -1 {
+		return
+	}
+	mt.values[i] = value
+}
+
+// String renders the value row of the table.
+func (mt *metadataTable) String() string {
+	return "| " + strings.Join(mt.values, " | ") + " |"
+}
+
+// indexOf returns the index of the header matching name, ignoring case.
+func indexOf(headers []string, name string) int {
+	for i, h := range headers {
+		if strings.EqualFold(h, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseTitle returns the text of the first level one heading.
+func parseTitle(lines []string) string {
+	for _, l := range lines {
+		if strings.HasPrefix(l, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(l, "# "))
+		}
+	}
+	return ""
+}
+
+// bumpVersion increments the patch number of a semantic version,
+// keeping the "v" prefix if one was used.
+//
+// Examples:
+//   - "v0.0.1" = "v0.0.2"
+//   - "1.2.9" = "1.2.10"
+func bumpVersion(version string) (string, error) {
+	prefix := ""
+	v := version
+	if strings.HasPrefix(v, "v") {
+		prefix = "v"
+		v = strings.TrimPrefix(v, "v")
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid version %q", version)
+	}
+
+	patch, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q", version)
+	}
+	parts[2] = strconv.Itoa(patch + 1)
+
+	return prefix + strings.Join(parts, "."), nil
+}
+
+// revisionEntry formats a line for the revision history.
+func revisionEntry(version, date, note string) string {
+	entry := fmt.Sprintf("- %s (%s)", version, date)
+	if note != "" {
+		entry += ": " + note
+	}
+	return entry
+}
+
+// findSection returns the line index of heading, or -1 if it isn't found.
+func findSection(lines []string, heading string) int {
+	for i, l := range lines {
+		if strings.TrimSpace(l) == heading {
+			return i
+		}
+	}
+	return -1
+}
+
+// sectionLines returns the lines between heading and the next heading.
+func sectionLines(lines []string, heading string) []string {
+	start := findSection(lines, heading)
+	if start == -1 {
+		return nil
+	}
+
+	end := start + 1
+	for end < len(lines) && !strings.HasPrefix(lines[end], "#") {
+		end++
+	}
+	return lines[start+1 : end]
+}
+
+// addToSection adds entry to the end of the section under heading.
+//
+// If the section doesn't exist it is created at the end of the document,
+// or before the revision history so that it stays the last section.
+func addToSection(lines []string, heading, entry string) []string {
+	start := findSection(lines, heading)
+	if start == -1 {
+		return addSection(lines, heading, entry)
+	}
+
+	// insert after the last entry in the section
+	insert := -1
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "#") {
+			break
+		}
+		if strings.TrimSpace(lines[i]) != "" {
+			insert = i + 1
+		}
+	}
+
+	newLines := []string{entry}
+	if insert == -1 {
+		insert = start + 1
+		newLines = []string{"", entry}
+	}
+
+	out := append([]string{}, lines[:insert]...)
+	out = append(out, newLines...)
+	return append(out, lines[insert:]...)
+}
+
+// addSection creates the section under heading containing entry.
+func addSection(lines []string, heading, entry string) []string {
+	if revision := findSection(lines, revisionHeading); revision != -1 &&
+		heading != revisionHeading {
+		out := append([]string{}, lines[:revision]...)
+		out = append(out, heading, "", entry, "")
+		return append(out, lines[revision:]...)
+	}
+
+	// add the section after the last line of the body
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	out := append([]string{}, lines[:end]...)
+	return append(out, "", heading, "", entry, "")
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetadataTable(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		row     int
+		status  string
+		version string
+		err     bool
+	}{
+		"default_template": {
+			content: "# Title\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | Author | 2025-01-05 | N/A | v0.0.1 |\n\n## Context and Problem Statement\n",
+			row:     4,
+			status:  "Draft",
+			version: "v0.0.1",
+			err:     false,
+		},
+		"other_tables_first": {
+			content: "# Title\n\n| A | B |\n| - | - |\n| 1 | 2 |\n\n| Status | Current Version |\n| :----- | --------------- |\n| Accepted | v1.0.0 |\n",
+			row:     8,
+			status:  "Accepted",
+			version: "v1.0.0",
+			err:     false,
+		},
+		"no_table": {
+			content: "# Title\n\nJust text\n",
+			err:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			table, err := parseMetadataTable(strings.Split(test.content, "\n"))
+			if test.err {
+				assert.Error(t, err, "")
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.row, table.row, "")
+			assert.Equal(t, test.status, table.Get(headerStatus), "")
+			assert.Equal(t, test.version, table.Get(headerVersion), "")
+		})
+	}
+}
+
+func TestMetadataTableSet(t *testing.T) {
+	table := &metadataTable{
+		headers: []string{"Status", "Last Update", "Current Version"},
+		values:  []string{"Draft", "N/A", "v0.0.1"},
+	}
+	table.Set(headerLastUpdate, "2025-01-05")
+	table.Set("Missing", "ignored")
+
+	assert.Equal(t, "| Draft | 2025-01-05 | v0.0.1 |", table.String(), "")
+}
+
+func TestBumpVersion(t *testing.T) {
+	tests := map[string]struct {
+		version  string
+		expected string
+		err      bool
+	}{
+		"prefixed":   {version: "v0.0.1", expected: "v0.0.2"},
+		"no_prefix":  {version: "1.2.9", expected: "1.2.10"},
+		"bad_patch":  {version: "v0.0.x", err: true},
+		"not_semver": {version: "N/A", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := bumpVersion(test.version)
+			assert.Equal(t, test.expected, actual, "")
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+			}
+		})
+	}
+}
+
+func TestAddToSection(t *testing.T) {
+	tests := map[string]struct {
+		content  string
+		expected string
+	}{
+		"new_section": {
+			content:  "# Title\n\n## Decision Outcome\n\nUse it\n\n",
+			expected: "# Title\n\n## Decision Outcome\n\nUse it\n\n## Revision History\n\n- v0.0.2 (2025-01-05): note\n",
+		},
+		"existing_section": {
+			content:  "# Title\n\n## Revision History\n\n- v0.0.2 (2025-01-04): first\n\n## Notes\n",
+			expected: "# Title\n\n## Revision History\n\n- v0.0.2 (2025-01-04): first\n- v0.0.2 (2025-01-05): note\n\n## Notes\n",
+		},
+		"empty_section": {
+			content:  "# Title\n\n## Revision History\n",
+			expected: "# Title\n\n## Revision History\n\n- v0.0.2 (2025-01-05): note\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lines := addToSection(
+				strings.Split(test.content, "\n"),
+				revisionHeading,
+				revisionEntry("v0.0.2", "2025-01-05", "note"),
+			)
+			assert.Equal(t, test.expected, strings.Join(lines, "\n"), "")
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ParseError is returned by Parse when an ADR file can be read but not
+// parsed, IE: its front matter is invalid.
+type ParseError struct {
+	File string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return e.File + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads an ADR markdown file from disk and returns it as an ADR.
+//
+// Metadata is read from the YAML front matter first, then the metadata
+// table, the first heading and finally the file name. The Body of the
+// returned ADR is the markdown following the front matter.
+func Parse(file string) (*ADR, error) {
+	b, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(file, b)
+}
+
+// parse builds an ADR from the contents of the file at path.
+func parse(path string, data []byte) (*ADR, error) {
+	fm, body, err := splitFrontMatter(string(data))
+	if err != nil {
+		return nil, &ParseError{File: path, Err: err}
+	}
+
+	lines := strings.Split(body, "\n")
+	id, name := nameParts(filepath.Base(path))
+
+	a := &ADR{
+		ID:   id,
+		File: path,
+		Body: body,
+		Content: Content{
+			Title: parseTitle(lines),
+		},
+	}
+
+	if table, err := parseMetadataTable(lines); err == nil {
+		a.Content.Status = table.Get(headerStatus)
+		a.Content.Author = table.Get(headerAuthor)
+		a.Content.Date = table.Get(headerCreated)
+		a.Content.Updated = notApplicable(table.Get(headerLastUpdate))
+		a.Content.Version = table.Get(headerVersion)
+	}
+
+	a.Content.Relations = parseRelations(lines)
+
+	fm.apply(a)
+
+	if a.Content.Title == "" {
+		a.Content.Title = name
+	}
+
+	return a, nil
+}
+
+// nameParts splits an ADR file name into its id and the remainder of the
+// name. Files not starting with an id return 0.
+//
+// Name examples:
+//   - "1-my-adr.md" = 1, "my-adr"
+func nameParts(file string) (int, string) {
+	idTitle := strings.SplitN(strings.TrimSuffix(file, ".md"), "-", 2)
+	id, err := strconv.Atoi(idTitle[0])
+	if err != nil {
+		return 0, idTitle[0]
+	}
+	if len(idTitle) == 1 {
+		return id, ""
+	}
+	return id, idTitle[1]
+}
+
+// notApplicable clears placeholder values used in the metadata table.
+func notApplicable(v string) string {
+	if strings.EqualFold(v, "N/A") {
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	parsePath := "tests/parse/adr/"
+	err := createTestFolder(parsePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		file     string
+		content  string
+		expected *ADR
+		err      bool
+	}{
+		"table": {
+			file:    "4-use-postgres.md",
+			content: "# Use Postgres\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Accepted | Jane Doe | 2025-01-05 | N/A | v0.0.1 |\n\n## Context and Problem Statement\n",
+			expected: &ADR{
+				ID:   4,
+				File: parsePath + "4-use-postgres.md",
+				Body: "# Use Postgres\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Accepted | Jane Doe | 2025-01-05 | N/A | v0.0.1 |\n\n## Context and Problem Statement\n",
+				Content: Content{
+					Title:   "Use Postgres",
+					Author:  "Jane Doe",
+					Status:  "Accepted",
+					Date:    "2025-01-05",
+					Version: "v0.0.1",
+				},
+			},
+		},
+		"front_matter": {
+			file:    "5-cache.md",
+			content: "---\nid: 7\ntitle: Use Redis\nstatus: Proposed\nauthors:\n  - Jane Doe\n  - John Doe\ndeciders: [Alice]\ndate: 2025-02-01\nupdated: 2025-02-03\ntags: [cache, database]\nversion: v0.0.2\nlayout: adr\n---\n\n# Cache\n",
+			expected: &ADR{
+				ID:   7,
+				File: parsePath + "5-cache.md",
+				Body: "# Cache\n",
+				Content: Content{
+					Title:    "Use Redis",
+					Author:   "Jane Doe, John Doe",
+					Status:   "Proposed",
+					Date:     "2025-02-01",
+					Updated:  "2025-02-03",
+					Version:  "v0.0.2",
+					Tags:     []string{"cache", "database"},
+					Deciders: []string{"Alice"},
+					Fields:   map[string]any{"layout": "adr"},
+				},
+			},
+		},
+		"front_matter_and_table": {
+			file:    "6-mixed.md",
+			content: "---\nstatus: Accepted\n---\n# Mixed\n\n| Status | Author |\n| ------ | ------ |\n| Draft | Bob |\n",
+			expected: &ADR{
+				ID:   6,
+				File: parsePath + "6-mixed.md",
+				Body: "# Mixed\n\n| Status | Author |\n| ------ | ------ |\n| Draft | Bob |\n",
+				Content: Content{
+					Title:  "Mixed",
+					Author: "Bob",
+					Status: "Accepted",
+				},
+			},
+		},
+		"empty": {
+			file:    "8-empty-file.md",
+			content: "",
+			expected: &ADR{
+				ID:   8,
+				File: parsePath + "8-empty-file.md",
+				Content: Content{
+					Title: "empty-file",
+				},
+			},
+		},
+		"unclosed_front_matter": {
+			file:    "9-unclosed.md",
+			content: "---\ntitle: Unclosed\n# Unclosed\n",
+			err:     true,
+		},
+		"bad_front_matter": {
+			file:    "10-bad.md",
+			content: "---\ntags: [one\n---\n# Bad\n",
+			err:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := os.WriteFile(parsePath+test.file, []byte(test.content), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := Parse(parsePath + test.file)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, actual, "")
+		})
+	}
+}
+
+func TestParseMissingFile(t *testing.T) {
+	_, err := Parse("tests/parse/adr/missing.md")
+	assert.Error(t, err, "")
+}
+
+func TestNameParts(t *testing.T) {
+	tests := map[string]struct {
+		file  string
+		id    int
+		title string
+	}{
+		"adr":     {file: "1-my-adr.md", id: 1, title: "my-adr"},
+		"padded":  {file: "0012-padded.md", id: 12, title: "padded"},
+		"id_only": {file: "3.md", id: 3, title: ""},
+		"no_id":   {file: "notes.md", id: 0, title: "notes"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, title := nameParts(test.file)
+			assert.Equal(t, test.id, id, "")
+			assert.Equal(t, test.title, title, "")
+		})
+	}
+}
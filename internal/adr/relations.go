@@ -0,0 +1,220 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Relation types between ADR's. Each type is recorded on one ADR with its
+// inverse recorded on the other.
+const (
+	Supersedes   = "supersedes"
+	SupersededBy = "superseded-by"
+	Amends       = "amends"
+	AmendedBy    = "amended-by"
+	RelatesTo    = "relates-to"
+)
+
+// relatedHeading is the section relations are recorded under.
+const relatedHeading = "## Related ADRs"
+
+// relationLabels are the labels used when rendering a relation.
+var relationLabels = map[string]string{
+	Supersedes:   "Supersedes",
+	SupersededBy: "Superseded by",
+	Amends:       "Amends",
+	AmendedBy:    "Amended by",
+	RelatesTo:    "Relates to",
+}
+
+// inverseRelations maps a relation to the one recorded on the other ADR.
+var inverseRelations = map[string]string{
+	Supersedes:   SupersededBy,
+	SupersededBy: Supersedes,
+	Amends:       AmendedBy,
+	AmendedBy:    Amends,
+	RelatesTo:    RelatesTo,
+}
+
+// relationEntry matches a relation in the related ADRs section.
+//
+//   - "- Supersedes [ADR 3: Use MySQL](3-Use-MySQL.md)"
+var relationEntry = regexp.MustCompile(
+	`^- (Supersedes|Superseded by|Amends|Amended by|Relates to) \[([^\]]*)\]\(([^)]*)\)`,
+)
+
+// Relation links an ADR to another ADR.
+type Relation struct {
+	Type  string
+	ID    int
+	Title string
+	File  string
+}
+
+// Label returns the text used for the relation type, IE: "Superseded by".
+func (r Relation) Label() string {
+	return relationLabels[r.Type]
+}
+
+// String returns the relation as text, IE: "Superseded by ADR 7".
+func (r Relation) String() string {
+	return fmt.Sprintf("%s ADR %d", r.Label(), r.ID)
+}
+
+// entry returns the relation as a markdown list item linking to the ADR.
+func (r Relation) entry() string {
+	return fmt.Sprintf(
+		"- %s [ADR %d: %s](%s)",
+		r.Label(),
+		r.ID,
+		r.Title,
+		r.File,
+	)
+}
+
+// Relate records the relation from the ADR with id to the ADR with id
+// other, and the inverse relation on other. Relations that are already
+// recorded are left as is.
+func (adr *ADR) Relate(id int, relation string, other int) error {
+	inverse, ok := inverseRelations[relation]
+	if !ok {
+		return fmt.Errorf("unknown relation %q", relation)
+	}
+
+	if id == other {
+		return fmt.Errorf("ADR %d can't be related to itself", id)
+	}
+
+	from, err := adr.Load(id)
+	if err != nil {
+		return err
+	}
+
+	to, err := adr.Load(other)
+	if err != nil {
+		return err
+	}
+
+	err = adr.addRelation(from, Relation{
+		Type:  relation,
+		ID:    to.ID,
+		Title: to.Content.Title,
+		File:  filepath.Base(to.File),
+	})
+	if err != nil {
+		return err
+	}
+
+	return adr.addRelation(to, Relation{
+		Type:  inverse,
+		ID:    from.ID,
+		Title: from.Content.Title,
+		File:  filepath.Base(from.File),
+	})
+}
+
+// Supersede records that the ADR with id by supersedes the ADR with id old
+// and changes the status of old to Superseded. Unless force is set the
+// status change must be allowed by the configured Workflow.
+func (adr *ADR) Supersede(old, by int, force bool) (*ADR, error) {
+	current, err := adr.Load(old)
+	if err != nil {
+		return nil, err
+	}
+
+	status := "Superseded"
+	if !force {
+		workflow, err := NewWorkflow()
+		if err != nil {
+			return nil, err
+		}
+
+		status, err = workflow.Transition(current.Content.Status, status)
+		if err != nil {
+			return nil, fmt.Errorf("ADR %d: %w", old, err)
+		}
+	}
+
+	err = adr.Relate(by, Supersedes, old)
+	if err != nil {
+		return nil, err
+	}
+
+	return adr.SetStatus(old, status, true)
+}
+
+// addRelation records the relation on a, both in its front matter and the
+// related ADRs section.
+func (adr *ADR) addRelation(a *ADR, r Relation) error {
+	if hasRelation(a.Content.Relations, r.Type, r.ID) {
+		return nil
+	}
+
+	return adr.rewrite(a.ID, func(a *ADR) error {
+		a.Content.Relations = append(a.Content.Relations, r)
+		a.addToSection(relatedHeading, r.entry())
+		return nil
+	})
+}
+
+// hasRelation reports if relations contains the relation to id.
+func hasRelation(relations []Relation, relation string, id int) bool {
+	for _, r := range relations {
+		if r.Type == relation && r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRelations returns the relations listed in the related ADRs section.
+func parseRelations(lines []string) []Relation {
+	var relations []Relation
+	for _, l := range sectionLines(lines, relatedHeading) {
+		m := relationEntry.FindStringSubmatch(strings.TrimSpace(l))
+		if m == nil {
+			continue
+		}
+
+		r := Relation{File: m[3]}
+		for t, label := range relationLabels {
+			if label == m[1] {
+				r.Type = t
+			}
+		}
+
+		r.ID, _ = nameParts(filepath.Base(m[3]))
+		r.Title = m[2]
+		if title, ok := strings.CutPrefix(m[2], "ADR "+strconv.Itoa(r.ID)+": "); ok {
+			r.Title = title
+		}
+
+		relations = append(relations, r)
+	}
+	return relations
+}
@@ -0,0 +1,182 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRelationADRs(path string) error {
+	err := createTestFolder(path)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		"1-Use-MySQL.md":    "# Use MySQL\n\n| Status | Author |\n| ------ | ------ |\n| Accepted | Author |\n\n## Decision Outcome\n",
+		"2-Use-Postgres.md": "# Use Postgres\n\n| Status | Author |\n| ------ | ------ |\n| Draft | Author |\n\n## Decision Outcome\n\n## Revision History\n\n- v0.0.2 (2025-01-05)\n",
+		"3-Use-Redis.md":    "# Use Redis\n\n| Status | Author |\n| ------ | ------ |\n| Draft | Author |\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(path+name, []byte(content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRelate(t *testing.T) {
+	relatePath := "tests/relate/adr/"
+	err := createRelationADRs(relatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		id       int
+		relation string
+		other    int
+		err      bool
+	}{
+		{name: "amends", id: 2, relation: Amends, other: 1},
+		{name: "duplicate", id: 2, relation: Amends, other: 1},
+		{name: "relates_to", id: 3, relation: RelatesTo, other: 2},
+		{name: "self", id: 1, relation: RelatesTo, other: 1, err: true},
+		{name: "unknown", id: 1, relation: "blocks", other: 2, err: true},
+		{name: "missing", id: 1, relation: RelatesTo, other: 9, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", relatePath)
+			a := NewADR()
+			err := a.Relate(test.id, test.relation, test.other)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+			}
+		})
+	}
+
+	expected := map[string]string{
+		"1-Use-MySQL.md":    "---\nid: 1\ntitle: Use MySQL\nstatus: Accepted\nauthors:\n  - Author\namended_by:\n  - 2\n---\n\n# Use MySQL\n\n| Status | Author |\n| ------ | ------ |\n| Accepted | Author |\n\n## Decision Outcome\n\n## Related ADRs\n\n- Amended by [ADR 2: Use Postgres](2-Use-Postgres.md)\n",
+		"2-Use-Postgres.md": "---\nid: 2\ntitle: Use Postgres\nstatus: Draft\nauthors:\n  - Author\namends:\n  - 1\nrelates_to:\n  - 3\n---\n\n# Use Postgres\n\n| Status | Author |\n| ------ | ------ |\n| Draft | Author |\n\n## Decision Outcome\n\n## Related ADRs\n\n- Amends [ADR 1: Use MySQL](1-Use-MySQL.md)\n- Relates to [ADR 3: Use Redis](3-Use-Redis.md)\n\n## Revision History\n\n- v0.0.2 (2025-01-05)\n",
+		"3-Use-Redis.md":    "---\nid: 3\ntitle: Use Redis\nstatus: Draft\nauthors:\n  - Author\nrelates_to:\n  - 2\n---\n\n# Use Redis\n\n| Status | Author |\n| ------ | ------ |\n| Draft | Author |\n\n## Related ADRs\n\n- Relates to [ADR 2: Use Postgres](2-Use-Postgres.md)\n",
+	}
+	for name, content := range expected {
+		b, err := os.ReadFile(relatePath + name)
+		assert.Nil(t, err, "")
+		assert.Equal(t, content, string(b), name)
+	}
+
+	viper.Set("adr.path", relatePath)
+	a, err := NewADR().Load(2)
+	assert.Nil(t, err, "")
+	assert.Equal(
+		t,
+		[]Relation{
+			{Type: Amends, ID: 1, Title: "Use MySQL", File: "1-Use-MySQL.md"},
+			{Type: RelatesTo, ID: 3, Title: "Use Redis", File: "3-Use-Redis.md"},
+		},
+		a.Content.Relations,
+		"",
+	)
+}
+
+func TestSupersede(t *testing.T) {
+	supersedePath := "tests/supersede/adr/"
+	err := createRelationADRs(supersedePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		old    int
+		by     int
+		force  bool
+		status string
+		err    bool
+	}{
+		{name: "not_allowed", old: 3, by: 2, status: "Draft", err: true},
+		{name: "allowed", old: 1, by: 2, status: "Superseded"},
+		{name: "force", old: 3, by: 2, force: true, status: "Superseded"},
+		{name: "missing", old: 9, by: 2, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", supersedePath)
+			a := NewADR()
+			_, err := a.Supersede(test.old, test.by, test.force)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+			}
+
+			if test.status != "" {
+				old, err := a.Load(test.old)
+				assert.Nil(t, err, "")
+				assert.Equal(t, test.status, old.Content.Status, "")
+			}
+		})
+	}
+
+	b, err := os.ReadFile(supersedePath + "2-Use-Postgres.md")
+	assert.Nil(t, err, "")
+	assert.Contains(
+		t,
+		string(b),
+		"## Related ADRs\n\n- Supersedes [ADR 1: Use MySQL](1-Use-MySQL.md)\n- Supersedes [ADR 3: Use Redis](3-Use-Redis.md)\n",
+		"",
+	)
+}
+
+func TestParseRelations(t *testing.T) {
+	content := "# Title\n\n## Related ADRs\n\n- Superseded by [ADR 7: New](7-New.md)\n- Relates to [Other](0003-other.md)\n- Not a relation\n\n## Notes\n\n- Amends [ADR 1: Ignored](1-ignored.md)\n"
+
+	assert.Equal(
+		t,
+		[]Relation{
+			{Type: SupersededBy, ID: 7, Title: "New", File: "7-New.md"},
+			{Type: RelatesTo, ID: 3, Title: "Other", File: "0003-other.md"},
+		},
+		parseRelations(strings.Split(content, "\n")),
+		"",
+	)
+}
+
+func TestRelationString(t *testing.T) {
+	r := Relation{Type: SupersededBy, ID: 7}
+	assert.Equal(t, "Superseded by", r.Label(), "")
+	assert.Equal(t, "Superseded by ADR 7", r.String(), "")
+}
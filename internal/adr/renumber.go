@@ -0,0 +1,248 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Renumbered records an ADR that was given a new id by Renumber.
+type Renumbered struct {
+	ID      int
+	NewID   int
+	Title   string
+	File    string
+	NewFile string
+}
+
+// Renumber finds ADR's sharing an id, usually created on parallel branches,
+// and gives all but the first a new id. The first is the ADR with the
+// earliest date. ADR's created on the same day are ordered by the number of
+// other ADR's linking to them, most first, then by the oldest file
+// modification time and last by file name.
+//
+// Renumbered ADR's are renamed and every relation linking to them is
+// updated. If dryRun is set the changes are only returned.
+func (adr *ADR) Renumber(dryRun bool) ([]Renumbered, error) {
+	adrs, err := adr.List()
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := adr.Id()
+	if err != nil {
+		return nil, err
+	}
+
+	var moves []Renumbered
+	for _, dupes := range duplicates(adrs) {
+		for _, a := range dupes[1:] {
+			// keep the rest of the name, only the id changes
+			_, name := nameParts(filepath.Base(a.File))
+			file, err := adr.Config.fileName(next, name, a.Content.Title)
+			if err != nil {
+				return nil, err
+			}
+
+			moves = append(moves, Renumbered{
+				ID:      a.ID,
+				NewID:   next,
+				Title:   a.Content.Title,
+				File:    filepath.Base(a.File),
+				NewFile: file,
+			})
+			next++
+		}
+	}
+
+	if dryRun || len(moves) == 0 {
+		return moves, nil
+	}
+
+	for _, m := range moves {
+		err = adr.move(m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return moves, adr.updateReferences(moves)
+}
+
+// duplicates returns the ADR's sharing an id, grouped by id in the order
+// they keep their id.
+func duplicates(adrs []*ADR) [][]*ADR {
+	byID := map[int][]*ADR{}
+	var ids []int
+	for _, a := range adrs {
+		if _, ok := byID[a.ID]; !ok {
+			ids = append(ids, a.ID)
+		}
+		byID[a.ID] = append(byID[a.ID], a)
+	}
+
+	var dupes [][]*ADR
+	for _, id := range ids {
+		group := byID[id]
+		if len(group) < 2 {
+			continue
+		}
+
+		links := map[*ADR]int{}
+		modified := map[*ADR]time.Time{}
+		for _, a := range group {
+			links[a] = linksTo(adrs, a)
+			if info, err := os.Stat(a.File); err == nil {
+				modified[a] = info.ModTime()
+			}
+		}
+
+		slices.SortStableFunc(group, func(a, b *ADR) int {
+			if c := strings.Compare(a.Content.Date, b.Content.Date); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(links[b], links[a]); c != 0 {
+				return c
+			}
+			if c := modified[a].Compare(modified[b]); c != 0 {
+				return c
+			}
+			return strings.Compare(a.File, b.File)
+		})
+		dupes = append(dupes, group)
+	}
+
+	return dupes
+}
+
+// linksTo returns the number of other ADR's with a relation linking to the
+// file of target.
+func linksTo(adrs []*ADR, target *ADR) int {
+	file := filepath.Base(target.File)
+
+	var n int
+	for _, a := range adrs {
+		if a == target {
+			continue
+		}
+		if slices.ContainsFunc(a.Content.Relations, func(r Relation) bool {
+			return r.ID == target.ID && r.File == file
+		}) {
+			n++
+		}
+	}
+	return n
+}
+
+// move renames the ADR file and sets its new id.
+func (adr *ADR) move(m Renumbered) error {
+	from := filepath.Join(adr.Config.Path, m.File)
+	to := filepath.Join(adr.Config.Path, m.NewFile)
+
+	if _, err := os.Stat(to); err == nil {
+		return fmt.Errorf("can't renumber %s, %s already exists", m.File, m.NewFile)
+	}
+
+	err := os.Rename(from, to)
+	if err != nil {
+		return err
+	}
+
+	return rewriteFile(to, func(a *ADR) error {
+		a.ID = m.NewID
+		return nil
+	})
+}
+
+// updateReferences updates the relations linking to renumbered ADR's.
+// Relations are matched by the file they link to, relations only recorded
+// by id can't be told apart and are left as is.
+func (adr *ADR) updateReferences(moves []Renumbered) error {
+	files, err := adr.GetAdrFilesNames()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		file := filepath.Join(adr.Config.Path, f)
+		a, err := Parse(file)
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(a.Content.Relations, func(r Relation) bool {
+			return renumberedTo(moves, r) != nil
+		}) {
+			continue
+		}
+
+		err = rewriteFile(file, func(a *ADR) error {
+			lines := strings.Split(a.Body, "\n")
+			for i, r := range a.Content.Relations {
+				m := renumberedTo(moves, r)
+				if m == nil {
+					continue
+				}
+
+				updated := r
+				updated.ID = m.NewID
+				updated.File = m.NewFile
+				a.Content.Relations[i] = updated
+				replaceLine(lines, relatedHeading, r.entry(), updated.entry())
+			}
+			a.Body = strings.Join(lines, "\n")
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renumberedTo returns the move of the ADR r links to, or nil.
+func renumberedTo(moves []Renumbered, r Relation) *Renumbered {
+	for i, m := range moves {
+		if r.ID == m.ID && r.File == m.File {
+			return &moves[i]
+		}
+	}
+	return nil
+}
+
+// replaceLine replaces the line matching old in the section under heading.
+func replaceLine(lines []string, heading, old, replacement string) {
+	section := sectionLines(lines, heading)
+	for i, l := range section {
+		if strings.TrimSpace(l) == old {
+			section[i] = replacement
+		}
+	}
+}
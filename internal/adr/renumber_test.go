@@ -0,0 +1,186 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenumber(t *testing.T) {
+	renumberPath := "tests/renumber/adr/"
+	err := createTestFolder(renumberPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"1-Use-Go.md":       "---\nid: 1\ntitle: Use Go\nstatus: Accepted\ndate: \"2025-01-01\"\nsuperseded_by:\n  - 2\n---\n\n# Use Go\n\n## Related ADRs\n\n- Superseded by [ADR 2: Use Rust](2-Use-Rust.md)\n- Relates to [ADR 2: Use Postgres](2-Use-Postgres.md)\n",
+		"2-Use-Postgres.md": "---\nid: 2\ntitle: Use Postgres\nstatus: Draft\ndate: \"2025-02-01\"\n---\n\n# Use Postgres\n\n## Related ADRs\n\n- Relates to [ADR 1: Use Go](1-Use-Go.md)\n",
+		"2-Use-Rust.md":     "---\nid: 2\ntitle: Use Rust\nstatus: Draft\ndate: \"2025-03-01\"\n---\n\n# Use Rust\n\n## Related ADRs\n\n- Supersedes [ADR 1: Use Go](1-Use-Go.md)\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(renumberPath+name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Set("adr.path", renumberPath)
+	a := NewADR()
+
+	expected := []Renumbered{
+		{ID: 2, NewID: 3, Title: "Use Rust", File: "2-Use-Rust.md", NewFile: "3-Use-Rust.md"},
+	}
+
+	// a dry run doesn't change anything
+	moves, err := a.Renumber(true)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, moves)
+	_, err = os.Stat(renumberPath + "2-Use-Rust.md")
+	assert.NoError(t, err)
+
+	moves, err = a.Renumber(false)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, moves)
+
+	_, err = os.Stat(renumberPath + "2-Use-Rust.md")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	b, err := os.ReadFile(renumberPath + "3-Use-Rust.md")
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"---\nid: 3\ntitle: Use Rust\nstatus: Draft\ndate: \"2025-03-01\"\nsupersedes:\n  - 1\n---\n\n# Use Rust\n\n## Related ADRs\n\n- Supersedes [ADR 1: Use Go](1-Use-Go.md)\n",
+		string(b),
+	)
+
+	b, err = os.ReadFile(renumberPath + "1-Use-Go.md")
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"---\nid: 1\ntitle: Use Go\nstatus: Accepted\ndate: \"2025-01-01\"\nsuperseded_by:\n  - 3\nrelates_to:\n  - 2\n---\n\n# Use Go\n\n## Related ADRs\n\n- Superseded by [ADR 3: Use Rust](3-Use-Rust.md)\n- Relates to [ADR 2: Use Postgres](2-Use-Postgres.md)\n",
+		string(b),
+	)
+
+	// nothing left to renumber
+	moves, err = a.Renumber(false)
+	assert.NoError(t, err)
+	assert.Empty(t, moves)
+}
+
+func TestRenumberSkipped(t *testing.T) {
+	renumberPath := "tests/renumber-skipped/adr/"
+	err := createTestFolder(renumberPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the ADR that can't be parsed may be a duplicate, so nothing is renumbered
+	files := map[string]string{
+		"1-Use-Go.md":   "---\nid: 1\ntitle: Use Go\n---\n\n# Use Go\n",
+		"1-Broken.md":   "---\nid: [\n---\n\n# Broken\n",
+		"2-Use-Rust.md": "---\nid: 1\ntitle: Use Rust\n---\n\n# Use Rust\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(renumberPath+name, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Set("adr.path", renumberPath)
+	defer viper.Set("adr.path", defaultAdrPath)
+
+	moves, err := NewADR().Renumber(false)
+	var skipped *SkippedError
+	assert.ErrorAs(t, err, &skipped, "")
+	assert.Nil(t, moves, "")
+	assert.FileExists(t, renumberPath+"2-Use-Rust.md", "")
+}
+
+func TestRenumberSameDay(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		links    bool
+		modified map[string]time.Time
+		expected string
+	}{
+		"linked": {
+			links:    true,
+			modified: map[string]time.Time{"2-Alpha.md": day, "2-Beta.md": day.Add(time.Hour)},
+			expected: "2-Alpha.md",
+		},
+		"modified": {
+			modified: map[string]time.Time{"2-Alpha.md": day.Add(time.Hour), "2-Beta.md": day},
+			expected: "2-Alpha.md",
+		},
+		"name": {
+			modified: map[string]time.Time{"2-Alpha.md": day, "2-Beta.md": day},
+			expected: "2-Beta.md",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			renumberPath := "tests/renumber-" + name + "/adr/"
+			err := createTestFolder(renumberPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files := map[string]string{
+				"1-Use-Go.md": "---\nid: 1\ntitle: Use Go\n---\n\n# Use Go\n",
+				"2-Alpha.md":  "---\nid: 2\ntitle: Alpha\ndate: \"2025-01-01\"\n---\n\n# Alpha\n",
+				"2-Beta.md":   "---\nid: 2\ntitle: Beta\ndate: \"2025-01-01\"\n---\n\n# Beta\n",
+			}
+			if test.links {
+				files["1-Use-Go.md"] = "---\nid: 1\ntitle: Use Go\n---\n\n# Use Go\n\n## Related ADRs\n\n- Relates to [ADR 2: Beta](2-Beta.md)\n"
+			}
+			for name, content := range files {
+				err = os.WriteFile(renumberPath+name, []byte(content), 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for name, mtime := range test.modified {
+				err = os.Chtimes(renumberPath+name, mtime, mtime)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			viper.Set("adr.path", renumberPath)
+			defer viper.Set("adr.path", defaultAdrPath)
+
+			moves, err := NewADR().Renumber(true)
+			assert.NoError(t, err)
+			if assert.Len(t, moves, 1) {
+				assert.Equal(t, test.expected, moves[0].File)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/donaldgifford/rex/internal/fileutil"
+)
+
+// Reservation claims an ADR id before the ADR is written, so ADR's created
+// on parallel branches don't get the same id. Reservations are kept in the
+// file set by "adr.reservations", which is committed with the ADR's.
+type Reservation struct {
+	ID     int    `yaml:"id"`
+	Title  string `yaml:"title"`
+	Author string `yaml:"author,omitempty"`
+	Date   string `yaml:"date"`
+}
+
+// Reserve claims the next ADR id for an ADR with title. Creating an ADR with
+// the same title uses the reserved id, the reservation is removed with
+// Claim once the ADR is written.
+func (adr *ADR) Reserve(title, author, date string) (*Reservation, error) {
+	if adr.Config.Reservations == "" {
+		return nil, fmt.Errorf(
+			"no reservation file set, set adr.reservations in your .rex.yaml",
+		)
+	}
+
+	if title == "" {
+		return nil, fmt.Errorf("a title is required to reserve an ADR id")
+	}
+
+	reservations, err := readReservations(adr.Config.Reservations)
+	if err != nil {
+		return nil, err
+	}
+
+	if r := findReservation(reservations, title); r != nil {
+		return nil, fmt.Errorf("ADR %d is already reserved for %q", r.ID, r.Title)
+	}
+
+	id, err := adr.Id()
+	if err != nil {
+		return nil, err
+	}
+
+	r := Reservation{
+		ID:     id,
+		Title:  title,
+		Author: author,
+		Date:   date,
+	}
+
+	err = writeReservations(
+		adr.Config.Reservations,
+		append(reservations, r),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// reservation returns the reservation for title, or nil if there is no
+// reservation for title.
+func (adr *ADR) reservation(title string) (*Reservation, error) {
+	if adr.Config.Reservations == "" {
+		return nil, nil
+	}
+
+	reservations, err := readReservations(adr.Config.Reservations)
+	if err != nil {
+		return nil, err
+	}
+
+	return findReservation(reservations, title), nil
+}
+
+// Claim removes r from the reservation file once the ADR it was reserved
+// for has been written.
+func (adr *ADR) Claim(r *Reservation) error {
+	if r == nil || adr.Config.Reservations == "" {
+		return nil
+	}
+
+	reservations, err := readReservations(adr.Config.Reservations)
+	if err != nil {
+		return err
+	}
+
+	var rest []Reservation
+	for _, v := range reservations {
+		if v.ID != r.ID {
+			rest = append(rest, v)
+		}
+	}
+
+	return writeReservations(adr.Config.Reservations, rest)
+}
+
+// reservedIDs returns the ids held in the reservation file.
+func (adr *ADR) reservedIDs() ([]int, error) {
+	if adr.Config.Reservations == "" {
+		return nil, nil
+	}
+
+	reservations, err := readReservations(adr.Config.Reservations)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, r := range reservations {
+		ids = append(ids, r.ID)
+	}
+	return ids, nil
+}
+
+// findReservation returns the reservation for title ignoring case, or nil.
+func findReservation(reservations []Reservation, title string) *Reservation {
+	for _, r := range reservations {
+		if strings.EqualFold(strings.TrimSpace(r.Title), strings.TrimSpace(title)) {
+			return &r
+		}
+	}
+	return nil
+}
+
+// readReservations reads the reservation file. A missing file has no
+// reservations.
+func readReservations(file string) ([]Reservation, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var reservations []Reservation
+	err = yaml.Unmarshal(data, &reservations)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return reservations, nil
+}
+
+// writeReservations writes reservations to the reservation file.
+func writeReservations(file string, reservations []Reservation) error {
+	if reservations == nil {
+		reservations = []Reservation{}
+	}
+
+	data, err := yaml.Marshal(reservations)
+	if err != nil {
+		return err
+	}
+
+	return fileutil.WriteFile(file, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
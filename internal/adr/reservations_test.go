@@ -0,0 +1,100 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserve(t *testing.T) {
+	reservePath := "tests/reserve/adr/"
+	reservations := "tests/reserve/reservations.yaml"
+	err := createTestFolder(reservePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = createTestADRFile(reservePath + "1-Use-Go.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("adr.path", reservePath)
+	viper.Set("adr.reservations", "")
+	defer viper.Set("adr.reservations", "")
+
+	// reserving needs a reservation file
+	_, err = NewADR().Reserve("Use Postgres", "Author", "2025-01-05")
+	assert.Error(t, err)
+
+	viper.Set("adr.reservations", reservations)
+	a := NewADR()
+
+	r, err := a.Reserve("Use Postgres", "Author", "2025-01-05")
+	assert.NoError(t, err)
+	assert.Equal(t, &Reservation{ID: 2, Title: "Use Postgres", Author: "Author", Date: "2025-01-05"}, r)
+
+	_, err = a.Reserve("use postgres", "Author", "2025-01-05")
+	assert.Error(t, err, "title is already reserved")
+
+	r, err = a.Reserve("Use Redis", "", "2025-01-06")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, r.ID)
+
+	b, err := os.ReadFile(reservations)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"- id: 2\n  title: Use Postgres\n  author: Author\n  date: \"2025-01-05\"\n- id: 3\n  title: Use Redis\n  date: \"2025-01-06\"\n",
+		string(b),
+	)
+
+	// reserved ids are skipped
+	id, err := a.Id()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+
+	// creating a reserved ADR uses the reservation, it is kept until claimed
+	c, r, err := a.Create(&Content{Title: "Use Postgres", Status: "Draft"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.ID)
+	assert.Equal(t, &Reservation{ID: 2, Title: "Use Postgres", Author: "Author", Date: "2025-01-05"}, r)
+
+	b, err = os.ReadFile(reservations)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "title: Use Postgres", "")
+
+	assert.NoError(t, a.Claim(r))
+
+	c, r, err = a.Create(&Content{Title: "Use MySQL", Status: "Draft"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, c.ID)
+	assert.Nil(t, r)
+	assert.NoError(t, a.Claim(r), "claiming no reservation does nothing")
+
+	b, err = os.ReadFile(reservations)
+	assert.NoError(t, err)
+	assert.Equal(t, "- id: 3\n  title: Use Redis\n  date: \"2025-01-06\"\n", string(b))
+}
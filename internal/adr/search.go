@@ -0,0 +1,354 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/donaldgifford/rex/internal/markdown"
+)
+
+// Fields that can be used as filters in a search query, IE: "status:accepted".
+const (
+	filterStatus = "status"
+	filterTag    = "tag"
+	filterAuthor = "author"
+)
+
+// Weights used to rank search results.
+const (
+	weightTitle    = 10
+	weightTag      = 5
+	weightMetadata = 2
+	maxBodyHits    = 5
+)
+
+// snippetRadius is the number of characters kept either side of the first
+// match in a snippet.
+const snippetRadius = 60
+
+// Query is a parsed search query.
+//
+// Terms must all be found in an ADR's title, body or metadata. Filters must
+// all match the field they are for.
+type Query struct {
+	Terms   []string
+	Filters map[string][]string
+}
+
+// SearchResult is an ADR matching a search with its rank and a snippet of
+// its body around the first match.
+type SearchResult struct {
+	ADR     *ADR
+	Score   int
+	Snippet string
+}
+
+// ParseQuery splits q into terms and field filters. Double quotes keep
+// words together as a single term or filter value.
+//
+// Examples:
+//   - `cache status:accepted` = Terms: ["cache"], Filters: {status: [accepted]}
+//   - `"event sourcing" tag:"data store"` = Terms: ["event sourcing"], Filters: {tag: ["data store"]}
+func ParseQuery(q string) Query {
+	query := Query{Filters: map[string][]string{}}
+
+	for _, token := range tokenize(q) {
+		field, value, ok := strings.Cut(token, ":")
+		field = strings.ToLower(field)
+		if ok && value != "" && isFilter(field) {
+			query.Filters[field] = append(query.Filters[field], value)
+			continue
+		}
+		query.Terms = append(query.Terms, token)
+	}
+
+	return query
+}
+
+// Search returns the ADR's matching query ordered by rank, the best match
+// first. ADR's with the same rank are ordered by id.
+//
+// ADR files that can't be parsed aren't searched, the results are returned
+// with the *SkippedError from List.
+func (adr *ADR) Search(query string) ([]SearchResult, error) {
+	q := ParseQuery(query)
+	if len(q.Terms) == 0 && len(q.Filters) == 0 {
+		return nil, fmt.Errorf("no search query given")
+	}
+
+	adrs, listErr := adr.List()
+	var skipped *SkippedError
+	if listErr != nil && !errors.As(listErr, &skipped) {
+		return nil, listErr
+	}
+
+	var results []SearchResult
+	for _, a := range adrs {
+		if !q.matchFilters(a) {
+			continue
+		}
+
+		score, ok := q.score(a)
+		if !ok {
+			continue
+		}
+
+		results = append(results, SearchResult{
+			ADR:     a,
+			Score:   score,
+			Snippet: snippet(a.Prose(), q.Terms),
+		})
+	}
+
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		return a.ADR.ID - b.ADR.ID
+	})
+
+	return results, listErr
+}
+
+// matchFilters reports if the ADR matches every filter in the query.
+func (q Query) matchFilters(a *ADR) bool {
+	for field, values := range q.Filters {
+		for _, v := range values {
+			var ok bool
+			switch field {
+			case filterStatus:
+				ok = strings.EqualFold(a.Content.Status, v)
+			case filterTag:
+				ok = slices.ContainsFunc(a.Content.Tags, func(t string) bool {
+					return strings.EqualFold(t, v)
+				})
+			case filterAuthor:
+				ok = indexFold(a.Content.Author, v) != -1
+			}
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// score ranks the ADR against the terms in the query. Returns false if a
+// term is not found in the ADR.
+func (q Query) score(a *ADR) (int, bool) {
+	body := a.Prose()
+	metadata := append([]string{a.Content.Author, a.Content.Status}, a.Content.Deciders...)
+
+	total := 0
+	for _, term := range q.Terms {
+		score := 0
+		if indexFold(a.Content.Title, term) != -1 {
+			score += weightTitle
+		}
+		if slices.ContainsFunc(a.Content.Tags, func(t string) bool {
+			return indexFold(t, term) != -1
+		}) {
+			score += weightTag
+		}
+		if slices.ContainsFunc(metadata, func(m string) bool {
+			return indexFold(m, term) != -1
+		}) {
+			score += weightMetadata
+		}
+		score += min(countFold(body, term), maxBodyHits)
+
+		if score == 0 {
+			return 0, false
+		}
+		total += score
+	}
+
+	return total, true
+}
+
+// snippet returns the first line of body containing one of the terms,
+// shortened to the text around the match.
+func snippet(body string, terms []string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || isHeading(line) || markdown.IsSeparatorRow(line) {
+			continue
+		}
+
+		start, end := -1, -1
+		for _, term := range terms {
+			i := indexFold(line, term)
+			if i != -1 && (start == -1 || i < start) {
+				start, end = i, i+matchFold(line[i:], term)
+			}
+		}
+		if start == -1 {
+			continue
+		}
+
+		return shorten(line, start, end)
+	}
+	return ""
+}
+
+// shorten cuts line down to at most snippetRadius characters either side of
+// the match between start and end, breaking on spaces and marking cut text
+// with "...".
+func shorten(line string, start, end int) string {
+	from := start
+	for n := 0; from > 0 && n < snippetRadius; n++ {
+		_, size := utf8.DecodeLastRuneInString(line[:from])
+		from -= size
+	}
+	to := end
+	for n := 0; to < len(line) && n < snippetRadius; n++ {
+		_, size := utf8.DecodeRuneInString(line[to:])
+		to += size
+	}
+
+	if i := strings.IndexByte(line[from:start], ' '); from > 0 && i != -1 {
+		from += i + 1
+	}
+	if i := strings.LastIndexByte(line[end:to], ' '); to < len(line) && i != -1 {
+		to = end + i
+	}
+
+	s := line[from:to]
+	if from > 0 {
+		s = "..." + s
+	}
+	if to < len(line) {
+		s += "..."
+	}
+	return s
+}
+
+// Highlight wraps every match of terms in text with before and after,
+// ignoring case.
+func Highlight(text string, terms []string, before, after string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		n := 0
+		for _, term := range terms {
+			n = max(n, matchFold(text[i:], term))
+		}
+		if n > 0 {
+			b.WriteString(before + text[i:i+n] + after)
+			i += n
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		b.WriteString(text[i : i+size])
+		i += size
+	}
+
+	return b.String()
+}
+
+// indexFold returns the byte index of the first match of term in s ignoring
+// case, or -1 if there is none.
+func indexFold(s, term string) int {
+	if term == "" {
+		return -1
+	}
+	for i := range s {
+		if matchFold(s[i:], term) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// countFold returns the number of matches of term in s ignoring case.
+func countFold(s, term string) int {
+	count := 0
+	for i := 0; i < len(s); {
+		j := indexFold(s[i:], term)
+		if j == -1 {
+			break
+		}
+		count++
+		i += j + matchFold(s[i+j:], term)
+	}
+	return count
+}
+
+// matchFold returns the length in bytes of term at the start of s ignoring
+// case, or 0 if s doesn't start with term.
+func matchFold(s, term string) int {
+	if term == "" {
+		return 0
+	}
+
+	n := 0
+	for _, t := range term {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if size == 0 || unicode.ToLower(r) != unicode.ToLower(t) {
+			return 0
+		}
+		n += size
+	}
+	return n
+}
+
+// tokenize splits q on spaces, keeping text in double quotes together.
+func tokenize(q string) []string {
+	var tokens []string
+	var b strings.Builder
+	quoted := false
+
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+
+	return tokens
+}
+
+// isFilter reports if field is one of the fields that can be filtered on.
+func isFilter(field string) bool {
+	switch field {
+	case filterStatus, filterTag, filterAuthor:
+		return true
+	}
+	return false
+}
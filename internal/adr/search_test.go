@@ -0,0 +1,178 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := map[string]struct {
+		query    string
+		expected Query
+	}{
+		"terms": {
+			query:    "cache  redis",
+			expected: Query{Terms: []string{"cache", "redis"}, Filters: map[string][]string{}},
+		},
+		"phrase_and_filters": {
+			query: `"event sourcing" Status:accepted tag:"data store" tag:db author:alice`,
+			expected: Query{
+				Terms: []string{"event sourcing"},
+				Filters: map[string][]string{
+					"status": {"accepted"},
+					"tag":    {"data store", "db"},
+					"author": {"alice"},
+				},
+			},
+		},
+		"unknown_field": {
+			query:    "https://example.com status:",
+			expected: Query{Terms: []string{"https://example.com", "status:"}, Filters: map[string][]string{}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ParseQuery(test.query), "")
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	searchPath := "tests/search/adr/"
+	err := createTestFolder(searchPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"1-use-postgres.md": "---\nid: 1\ntitle: Use Postgres\nstatus: Accepted\nauthor: Alice\ntags: [database]\n---\n# Use Postgres\n\n## Decision\n\nWe store everything in Postgres.\n",
+		"2-cache.md":        "---\nid: 2\ntitle: Cache sessions\nstatus: Proposed\nauthor: Bob\ntags: [cache]\n---\n# Cache sessions\n\n## Context\n\nSessions in Postgres add load to the database.\n",
+		"3-queue.md":        "---\nid: 3\ntitle: Use a queue\nstatus: Accepted\nauthor: Alice Smith\n---\n# Use a queue\n\nJobs are retried.\n",
+	}
+	for name, content := range files {
+		err = os.WriteFile(searchPath+name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Set("adr.path", searchPath)
+	a := NewADR()
+
+	tests := map[string]struct {
+		query    string
+		ids      []int
+		snippets []string
+		err      bool
+	}{
+		"ranked":        {query: "postgres", ids: []int{1, 2}, snippets: []string{"We store everything in Postgres.", "Sessions in Postgres add load to the database."}},
+		"every_term":    {query: "postgres load", ids: []int{2}},
+		"phrase":        {query: `"to the database"`, ids: []int{2}},
+		"metadata":      {query: "bob", ids: []int{2}},
+		"status":        {query: "status:accepted", ids: []int{1, 3}},
+		"tag":           {query: "postgres tag:cache", ids: []int{2}},
+		"author":        {query: "use author:alice", ids: []int{1, 3}},
+		"two_filters":   {query: "status:accepted author:smith", ids: []int{3}},
+		"no_match":      {query: "mysql", ids: nil},
+		"empty":         {query: "  ", err: true},
+		"filter_no_hit": {query: "tag:missing", ids: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			results, err := a.Search(test.query)
+			if test.err {
+				assert.Error(t, err, "")
+				return
+			}
+			assert.NoError(t, err, "")
+
+			var ids []int
+			var snippets []string
+			for _, r := range results {
+				ids = append(ids, r.ADR.ID)
+				snippets = append(snippets, r.Snippet)
+			}
+			assert.Equal(t, test.ids, ids, "")
+			if test.snippets != nil {
+				assert.Equal(t, test.snippets, snippets, "")
+			}
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := "Sessions are currently stored in Postgres which adds load to the primary database during peak hours and slows everything down for users of the application."
+
+	tests := map[string]struct {
+		body     string
+		terms    []string
+		expected string
+	}{
+		"first_match": {
+			body:     "## Postgres\n\nNo match here\nFirst postgres line\nSecond postgres line\n",
+			terms:    []string{"POSTGRES"},
+			expected: "First postgres line",
+		},
+		"shortened": {
+			body:     long,
+			terms:    []string{"primary"},
+			expected: "...are currently stored in Postgres which adds load to the primary database during peak hours and slows everything down for...",
+		},
+		"no_match": {
+			body:     "text",
+			terms:    []string{"other"},
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, snippet(test.body, test.terms), "")
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		terms    []string
+		expected string
+	}{
+		"ignores_case": {text: "Use Postgres and postgres", terms: []string{"postgres"}, expected: "Use [Postgres] and [postgres]"},
+		"longest":      {text: "cache caches", terms: []string{"cache", "caches"}, expected: "[cache] [caches]"},
+		"unicode":      {text: "Über alles", terms: []string{"über"}, expected: "[Über] alles"},
+		"no_terms":     {text: "text", terms: nil, expected: "text"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Highlight(test.text, test.terms, "[", "]"), "")
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Validate parses the ADR file and checks its metadata, returning every
+// problem found.
+//
+// The status must be part of the workflow and the id must match the id
+// in the file name.
+func Validate(file string) error {
+	a, err := Parse(file)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := NewWorkflow()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if a.Content.Status == "" {
+		errs = append(errs, fmt.Errorf("%s: no status set", file))
+	} else if workflow.Status(a.Content.Status) == "" {
+		errs = append(errs, fmt.Errorf(
+			"%s: unknown status %q, must be one of: %s",
+			file,
+			a.Content.Status,
+			strings.Join(workflow.Statuses(), ", "),
+		))
+	}
+
+	if id, ok := fileID(filepath.Base(file)); ok && id != a.ID {
+		errs = append(errs, fmt.Errorf(
+			"%s: id %d does not match the id %d in the file name",
+			file,
+			a.ID,
+			id,
+		))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	validatePath := "tests/validate/adr/"
+	err := createTestFolder(validatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		file    string
+		content string
+		err     string
+	}{
+		"valid": {
+			file:    "1-valid.md",
+			content: "---\nid: 1\ntitle: Valid\nstatus: accepted\n---\n# Valid\n",
+		},
+		"unknown_status": {
+			file:    "2-unknown.md",
+			content: "---\nid: 2\ntitle: Unknown\nstatus: Done\n---\n# Unknown\n",
+			err:     "tests/validate/adr/2-unknown.md: unknown status \"Done\", must be one of: Draft, Proposed, Rejected, Accepted, Deprecated, Superseded",
+		},
+		"every_problem": {
+			file:    "3-problems.md",
+			content: "---\nid: 4\ntitle: Problems\n---\n# Problems\n",
+			err:     "tests/validate/adr/3-problems.md: no status set\ntests/validate/adr/3-problems.md: id 4 does not match the id 3 in the file name",
+		},
+		"invalid_front_matter": {
+			file:    "5-invalid.md",
+			content: "---\nid: [\n---\n# Invalid\n",
+			err:     "tests/validate/adr/5-invalid.md: invalid front matter: yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			file := validatePath + test.file
+			err := os.WriteFile(file, []byte(test.content), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = Validate(file)
+			if test.err == "" {
+				assert.Nil(t, err, "")
+				return
+			}
+			assert.EqualError(t, err, test.err, "")
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package adr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Workflow is the set of statuses an ADR moves through and the transitions
+// allowed between them. It is configured under "adr.workflow" in .rex.yaml.
+type Workflow struct {
+	Initial     string       `mapstructure:"initial"     yaml:"initial"`
+	Transitions []Transition `mapstructure:"transitions" yaml:"transitions"`
+}
+
+// Transition lists the statuses an ADR in the From status can move to.
+type Transition struct {
+	From string   `mapstructure:"from" yaml:"from"`
+	To   []string `mapstructure:"to"   yaml:"to"`
+}
+
+// DefaultWorkflow returns the workflow used when "adr.workflow" isn't set.
+//
+//	Draft -> Proposed -> Accepted -> Deprecated/Superseded
+//
+// Draft and Proposed ADR's can also be Rejected.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Initial: "Draft",
+		Transitions: []Transition{
+			{From: "Draft", To: []string{"Proposed", "Rejected"}},
+			{From: "Proposed", To: []string{"Accepted", "Rejected", "Draft"}},
+			{From: "Accepted", To: []string{"Deprecated", "Superseded"}},
+			{From: "Deprecated", To: []string{}},
+			{From: "Superseded", To: []string{}},
+			{From: "Rejected", To: []string{}},
+		},
+	}
+}
+
+// NewWorkflow reads the workflow under "adr.workflow", falling back to the
+// DefaultWorkflow if it isn't configured.
+func NewWorkflow() (*Workflow, error) {
+	if !viper.IsSet("adr.workflow") {
+		return DefaultWorkflow(), nil
+	}
+
+	w := &Workflow{}
+	err := viper.UnmarshalKey("adr.workflow", w)
+	if err != nil {
+		return nil, fmt.Errorf("invalid adr.workflow: %w", err)
+	}
+
+	if w.Initial == "" {
+		return nil, fmt.Errorf("invalid adr.workflow: initial status not set")
+	}
+
+	return w, nil
+}
+
+// Statuses returns every status in the workflow in the order they are
+// configured.
+func (w *Workflow) Statuses() []string {
+	statuses := []string{w.Initial}
+	add := func(s string) {
+		if findStatus(statuses, s) == "" {
+			statuses = append(statuses, s)
+		}
+	}
+
+	for _, t := range w.Transitions {
+		add(t.From)
+		for _, to := range t.To {
+			add(to)
+		}
+	}
+	return statuses
+}
+
+// Status returns the configured spelling of status, or an empty string if
+// it isn't part of the workflow.
+func (w *Workflow) Status(status string) string {
+	return findStatus(w.Statuses(), status)
+}
+
+// Transition validates that an ADR can move from one status to another and
+// returns the new status as it is spelled in the workflow.
+func (w *Workflow) Transition(from, to string) (string, error) {
+	next := w.Status(to)
+	if next == "" {
+		return "", fmt.Errorf(
+			"unknown status %q, must be one of: %s",
+			to,
+			strings.Join(w.Statuses(), ", "),
+		)
+	}
+
+	current := w.Status(from)
+	if current == "" {
+		return "", fmt.Errorf(
+			"current status %q is not part of the workflow, use --force to set it",
+			from,
+		)
+	}
+
+	if current == next {
+		return "", fmt.Errorf("status is already %s", current)
+	}
+
+	for _, t := range w.Transitions {
+		if strings.EqualFold(t.From, current) &&
+			slices.ContainsFunc(t.To, func(s string) bool {
+				return strings.EqualFold(s, next)
+			}) {
+			return next, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"cannot change status from %s to %s, allowed: %s",
+		current,
+		next,
+		strings.Join(w.next(current), ", "),
+	)
+}
+
+// next returns the statuses that current can move to.
+func (w *Workflow) next(current string) []string {
+	for _, t := range w.Transitions {
+		if strings.EqualFold(t.From, current) && len(t.To) > 0 {
+			return t.To
+		}
+	}
+	return []string{"none"}
+}
+
+// findStatus returns the entry in statuses matching status, ignoring case.
+func findStatus(statuses []string, status string) string {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package adr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWorkflow(t *testing.T) {
+	tests := map[string]struct {
+		config   any
+		expected *Workflow
+		err      bool
+	}{
+		"default": {
+			config:   nil,
+			expected: DefaultWorkflow(),
+			err:      false,
+		},
+		"configured": {
+			config: map[string]any{
+				"initial": "Proposed",
+				"transitions": []any{
+					map[string]any{"from": "Proposed", "to": []any{"Approved"}},
+				},
+			},
+			expected: &Workflow{
+				Initial: "Proposed",
+				Transitions: []Transition{
+					{From: "Proposed", To: []string{"Approved"}},
+				},
+			},
+			err: false,
+		},
+		"no_initial": {
+			config: map[string]any{
+				"transitions": []any{},
+			},
+			err: true,
+		},
+		"invalid": {
+			config: "Draft",
+			err:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.workflow", test.config)
+			defer viper.Set("adr.workflow", nil)
+
+			actual, err := NewWorkflow()
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, actual, "")
+		})
+	}
+}
+
+func TestWorkflowStatuses(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{
+			"Draft",
+			"Proposed",
+			"Rejected",
+			"Accepted",
+			"Deprecated",
+			"Superseded",
+		},
+		DefaultWorkflow().Statuses(),
+		"",
+	)
+}
+
+func TestWorkflowTransition(t *testing.T) {
+	tests := map[string]struct {
+		from     string
+		to       string
+		expected string
+		err      bool
+	}{
+		"allowed":       {from: "Draft", to: "Proposed", expected: "Proposed"},
+		"ignores_case":  {from: "proposed", to: "accepted", expected: "Accepted"},
+		"not_allowed":   {from: "Draft", to: "Accepted", err: true},
+		"final":         {from: "Superseded", to: "Accepted", err: true},
+		"same":          {from: "Draft", to: "draft", err: true},
+		"unknown":       {from: "Draft", to: "Done", err: true},
+		"unknown_from":  {from: "WIP", to: "Proposed", err: true},
+		"empty_current": {from: "", to: "Proposed", err: true},
+	}
+
+	w := DefaultWorkflow()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := w.Transition(test.from, test.to)
+			assert.Equal(t, test.expected, actual, "")
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+			}
+		})
+	}
+}
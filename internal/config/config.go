@@ -26,14 +26,19 @@ THE SOFTWARE.
 package config
 
 import (
+	"errors"
+
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
+
+	"github.com/donaldgifford/rex/internal/adr"
 )
 
 // RexConfigure provides methods to configure and setup rex
 type RexConfigure interface {
 	Settings() *RexConfig
 	YamlOut() ([]byte, error)
+	Err() error
 }
 
 // NewRexConfigure creates new RexConfigure to use
@@ -47,19 +52,33 @@ type RexConfig struct {
 	Templates         TemplateConfig `yaml:"templates"`
 	EnableGithubPages bool           `yaml:"enable_github_pages"`
 	Pages             PagesConfig    `yaml:"pages"`
+	Site              SiteConfig     `yaml:"site,omitempty"`
+	Feed              FeedConfig     `yaml:"feed,omitempty"`
 	Extras            bool           `yaml:"extras"`
 	ExtraPages        ExtrasConfig   `yaml:"extra_pages"`
+
+	// err holds the errors reading settings, see Err.
+	err error
 }
 
 type ADRConfig struct {
-	Path       string `yaml:"path"`
-	IndexPage  string `yaml:"index_page"`
-	AddToIndex bool   `yaml:"add_to_index"`
+	Path            string        `yaml:"path"`
+	IndexPage       string        `yaml:"index_page"`
+	AddToIndex      bool          `yaml:"add_to_index"`
+	IDWidth         int           `yaml:"id_width"`
+	Reservations    string        `yaml:"reservations,omitempty"`
+	FilenamePattern string        `yaml:"filename_pattern,omitempty"`
+	IndexSort       string        `yaml:"index_sort,omitempty"`
+	IndexGroupBy    string        `yaml:"index_group_by,omitempty"`
+	EditOnCreate    bool          `yaml:"edit_on_create,omitempty"`
+	Workflow        *adr.Workflow `yaml:"workflow,omitempty"`
+	Fields          []adr.Field   `yaml:"fields,omitempty"`
 }
 
 type ADRTemplateConfig struct {
-	Default string `yaml:"default"`
-	Index   string `yaml:"index"`
+	Default string            `yaml:"default"`
+	Index   string            `yaml:"index"`
+	Named   map[string]string `yaml:"named,omitempty"`
 }
 
 type TemplateConfig struct {
@@ -69,10 +88,24 @@ type TemplateConfig struct {
 }
 
 type PagesConfig struct {
+	Path  string         `yaml:"path,omitempty"`
+	Title string         `yaml:"title,omitempty"`
 	Index string         `yaml:"index"`
 	Web   PagesConfigWeb `yaml:"web"`
 }
 
+type SiteConfig struct {
+	Path  string `yaml:"path,omitempty"`
+	Title string `yaml:"title,omitempty"`
+}
+
+type FeedConfig struct {
+	URL   string `yaml:"url,omitempty"`
+	Title string `yaml:"title,omitempty"`
+	RSS   bool   `yaml:"rss,omitempty"`
+	Limit int    `yaml:"limit,omitempty"`
+}
+
 type PagesConfigWeb struct {
 	Config string               `yaml:"config"`
 	Layout PagesConfigWebLayout `yaml:"layout"`
@@ -89,12 +122,25 @@ type ExtrasConfig struct {
 }
 
 // NewRexConfig creates an empty config object
+//
+// Settings that can't be read are left empty, Err returns why.
 func NewRexConfig() *RexConfig {
+	workflow, workflowErr := workflow()
+	fields, fieldsErr := fields()
+
 	return &RexConfig{
 		ADR: ADRConfig{
-			Path:       viper.GetString("adr.path"),
-			IndexPage:  viper.GetString("adr.index_page"),
-			AddToIndex: viper.GetBool("adr.add_to_index"),
+			Path:            viper.GetString("adr.path"),
+			IndexPage:       viper.GetString("adr.index_page"),
+			AddToIndex:      viper.GetBool("adr.add_to_index"),
+			IDWidth:         viper.GetInt("adr.id_width"),
+			Reservations:    viper.GetString("adr.reservations"),
+			FilenamePattern: viper.GetString("adr.filename_pattern"),
+			IndexSort:       viper.GetString("adr.index_sort"),
+			IndexGroupBy:    viper.GetString("adr.index_group_by"),
+			EditOnCreate:    viper.GetBool("adr.edit_on_create"),
+			Workflow:        workflow,
+			Fields:          fields,
 		},
 		Templates: TemplateConfig{
 			Enabled: viper.GetBool("templates.enabled"),
@@ -102,10 +148,13 @@ func NewRexConfig() *RexConfig {
 			ADR: ADRTemplateConfig{
 				Default: viper.GetString("templates.adr.default"),
 				Index:   viper.GetString("templates.adr.index"),
+				Named:   namedTemplates(),
 			},
 		},
 		EnableGithubPages: viper.GetBool("enable_github_pages"),
 		Pages: PagesConfig{
+			Path:  viper.GetString("pages.path"),
+			Title: viper.GetString("pages.title"),
 			Index: viper.GetString("pages.index"),
 			Web: PagesConfigWeb{
 				Config: viper.GetString("pages.web.config"),
@@ -115,19 +164,62 @@ func NewRexConfig() *RexConfig {
 				},
 			},
 		},
+		Site: SiteConfig{
+			Path:  viper.GetString("site.path"),
+			Title: viper.GetString("site.title"),
+		},
+		Feed: FeedConfig{
+			URL:   viper.GetString("feed.url"),
+			Title: viper.GetString("feed.title"),
+			RSS:   viper.GetBool("feed.rss"),
+			Limit: viper.GetInt("feed.limit"),
+		},
 		Extras: viper.GetBool("extras"),
 		ExtraPages: ExtrasConfig{
 			Install: viper.GetString("extra_pages.install"),
 			Usage:   viper.GetString("extra_pages.usage"),
 		},
+		err: errors.Join(workflowErr, fieldsErr),
 	}
 }
 
+// workflow returns the status workflow set in the config file. Returns
+// nil if it isn't set.
+func workflow() (*adr.Workflow, error) {
+	if !viper.IsSet("adr.workflow") {
+		return nil, nil
+	}
+
+	return adr.NewWorkflow()
+}
+
+// fields returns the fields set under "adr.fields". Returns nil if there
+// are none.
+func fields() ([]adr.Field, error) {
+	return adr.NewFields()
+}
+
+// namedTemplates returns the ADR templates under "templates.adr.named", nil
+// if there are none.
+func namedTemplates() map[string]string {
+	named := viper.GetStringMapString("templates.adr.named")
+	if len(named) == 0 {
+		return nil
+	}
+	return named
+}
+
 // Settings exposes settings out to use in other calls
 func (rc *RexConfig) Settings() *RexConfig {
 	return rc
 }
 
+// Err returns the errors reading the settings in NewRexConfig, nil if
+// they were all read.
+func (rc *RexConfig) Err() error {
+	return rc.err
+}
+
 // YamlOut is a helper which outputs the current RexConfig
 // settings to yaml
 func (rc *RexConfig) YamlOut() ([]byte, error) {
@@ -25,10 +25,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/rex/internal/adr"
 )
 
 var (
@@ -213,7 +216,7 @@ func TestRexConfig_NewRexConfigure(t *testing.T) {
 	}{
 		"output": {
 			cwd:      "",
-			expected: "adr:\n    path: tests/docs/adr/\n    index_page: README.md\n    add_to_index: true\ntemplates:\n    enabled: false\n    path: tests/docs/templates/\n    adr:\n        default: adr.tmpl\n        index: index.tmpl\nenable_github_pages: true\npages:\n    index: index.md\n    web:\n        config: _config.yml\n        layout:\n            adr: adr.html\n            default: default.html\nextras: true\nextra_pages:\n    install: install.md\n    usage: usage.md\n",
+			expected: "adr:\n    path: tests/docs/adr/\n    index_page: README.md\n    add_to_index: true\n    id_width: 0\ntemplates:\n    enabled: false\n    path: tests/docs/templates/\n    adr:\n        default: adr.tmpl\n        index: index.tmpl\nenable_github_pages: true\npages:\n    index: index.md\n    web:\n        config: _config.yml\n        layout:\n            adr: adr.html\n            default: default.html\nextras: true\nextra_pages:\n    install: install.md\n    usage: usage.md\n",
 			err:      false,
 		},
 	}
@@ -269,11 +272,11 @@ func TestNewRexConfig(t *testing.T) {
 
 	config := NewRexConfig()
 
-	if config.ADR != c.ADR {
+	if !reflect.DeepEqual(config.ADR, c.ADR) {
 		t.Errorf("ADR Settings dont match: %v, %v", config.ADR, c.ADR)
 	}
 
-	if config.Templates != c.Templates {
+	if !reflect.DeepEqual(config.Templates, c.Templates) {
 		t.Errorf(
 			"Templates settings dont match: %v, %v",
 			config.Templates,
@@ -322,11 +325,11 @@ func TestRexConfigSettings(t *testing.T) {
 	rc := NewRexConfig()
 	config := rc.Settings()
 
-	if config.ADR != c.ADR {
+	if !reflect.DeepEqual(config.ADR, c.ADR) {
 		t.Errorf("ADR Settings dont match: %v, %v", config.ADR, c.ADR)
 	}
 
-	if config.Templates != c.Templates {
+	if !reflect.DeepEqual(config.Templates, c.Templates) {
 		t.Errorf(
 			"Templates settings dont match: %v, %v",
 			config.Templates,
@@ -347,7 +350,7 @@ func TestRexConfig_YamlOut(t *testing.T) {
 	}{
 		"output": {
 			cwd:      "",
-			expected: "adr:\n    path: tests/docs/adr/\n    index_page: README.md\n    add_to_index: true\ntemplates:\n    enabled: false\n    path: tests/docs/templates/\n    adr:\n        default: adr.tmpl\n        index: index.tmpl\nenable_github_pages: true\npages:\n    index: index.md\n    web:\n        config: _config.yml\n        layout:\n            adr: adr.html\n            default: default.html\nextras: true\nextra_pages:\n    install: install.md\n    usage: usage.md\n",
+			expected: "adr:\n    path: tests/docs/adr/\n    index_page: README.md\n    add_to_index: true\n    id_width: 0\ntemplates:\n    enabled: false\n    path: tests/docs/templates/\n    adr:\n        default: adr.tmpl\n        index: index.tmpl\nenable_github_pages: true\npages:\n    index: index.md\n    web:\n        config: _config.yml\n        layout:\n            adr: adr.html\n            default: default.html\nextras: true\nextra_pages:\n    install: install.md\n    usage: usage.md\n",
 			err:      false,
 		},
 	}
@@ -367,3 +370,93 @@ func TestRexConfig_YamlOut(t *testing.T) {
 		})
 	}
 }
+
+func TestRexConfig_Workflow(t *testing.T) {
+	tests := map[string]struct {
+		workflow any
+		expected *adr.Workflow
+		err      bool
+	}{
+		"not_set": {
+			workflow: nil,
+			expected: nil,
+		},
+		"set": {
+			workflow: map[string]any{
+				"initial": "Proposed",
+				"transitions": []any{
+					map[string]any{"from": "Proposed", "to": []any{"Accepted"}},
+				},
+			},
+			expected: &adr.Workflow{
+				Initial: "Proposed",
+				Transitions: []adr.Transition{
+					{From: "Proposed", To: []string{"Accepted"}},
+				},
+			},
+		},
+		"invalid": {
+			workflow: "Draft",
+			expected: nil,
+			err:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.workflow", test.workflow)
+			defer viper.Set("adr.workflow", nil)
+
+			r := NewRexConfig()
+			assert.Equal(t, test.expected, r.ADR.Workflow, "")
+			if test.err {
+				assert.ErrorContains(t, r.Err(), "adr.workflow", "")
+			} else {
+				assert.Nil(t, r.Err(), "")
+			}
+		})
+	}
+}
+
+func TestRexConfig_Fields(t *testing.T) {
+	tests := map[string]struct {
+		fields   any
+		expected []adr.Field
+		err      bool
+	}{
+		"not_set": {
+			fields:   nil,
+			expected: nil,
+		},
+		"set": {
+			fields: []any{
+				map[string]any{"name": "jira", "required": true},
+				map[string]any{"name": "cost_impact", "type": "int", "default": "0"},
+			},
+			expected: []adr.Field{
+				{Name: "jira", Type: "string", Required: true},
+				{Name: "cost_impact", Type: "int", Default: "0"},
+			},
+		},
+		"invalid": {
+			fields:   []any{map[string]any{"name": "status"}},
+			expected: nil,
+			err:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.fields", test.fields)
+			defer viper.Set("adr.fields", nil)
+
+			r := NewRexConfig()
+			assert.Equal(t, test.expected, r.ADR.Fields, "")
+			if test.err {
+				assert.ErrorContains(t, r.Err(), "adr.fields", "")
+			} else {
+				assert.Nil(t, r.Err(), "")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package fileutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	writePath := t.TempDir()
+
+	file := filepath.Join(writePath, "file.md")
+	err := os.WriteFile(file, []byte("original"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		write    func(w io.Writer) error
+		expected string
+		err      bool
+	}{
+		{
+			name: "failed_write",
+			write: func(w io.Writer) error {
+				_, err := io.WriteString(w, "partial")
+				if err != nil {
+					return err
+				}
+				return errors.New("template failed")
+			},
+			expected: "original",
+			err:      true,
+		},
+		{
+			name: "write",
+			write: func(w io.Writer) error {
+				_, err := io.WriteString(w, "updated")
+				return err
+			},
+			expected: "updated",
+			err:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := WriteFile(file, test.write)
+			if test.err {
+				assert.Error(t, err, "")
+			} else {
+				assert.Nil(t, err, "")
+			}
+
+			b, err := os.ReadFile(file)
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, string(b), "")
+
+			// the file keeps its permissions
+			info, err := os.Stat(file)
+			assert.Nil(t, err, "")
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "")
+
+			// no temporary files are left behind
+			tmp, err := filepath.Glob(filepath.Join(writePath, ".*.tmp"))
+			assert.Nil(t, err, "")
+			assert.Empty(t, tmp, "")
+		})
+	}
+}
@@ -34,6 +34,7 @@ package install
 import (
 	"os"
 
+	"github.com/donaldgifford/rex/internal/adr"
 	"github.com/donaldgifford/rex/internal/config"
 )
 
@@ -42,15 +43,18 @@ var (
 	defaultAdrPath               string = "docs/adr/"
 	defaultAdrIndexPage          string = "README.md"
 	defaultAdrAddToIndex         bool   = true
+	defaultAdrIDWidth            int    = 0
 	defaultTemplatesPath         string = "templates/"
 	defaultTemplatesEnabled      bool   = false
 	defaultTemplatesAdrDefault   string = "adr.tmpl"
 	defaultTemplatesAdrIndex     string = "index.tmpl"
 	defaultEnabledGithubPages    bool   = true
+	defaultPagesPath             string = "pages/"
 	defaultPagesIndex            string = "index.md"
 	defaultPagesWebConfig        string = "_config.yml"
 	defaultPagesWebLayoutAdr     string = "adr.html"
 	defaultPagesWebLayoutDefault string = "default.html"
+	defaultSitePath              string = "public/"
 	defaultExtras                bool   = true
 	defaultExtraPagesInstall     string = "install.md"
 	defaultExtraPagesUsage       string = "usage.md"
@@ -66,6 +70,8 @@ func CreateRexConfigFile() error {
 			Path:       defaultAdrPath,
 			IndexPage:  defaultAdrIndexPage,
 			AddToIndex: defaultAdrAddToIndex,
+			IDWidth:    defaultAdrIDWidth,
+			Workflow:   adr.DefaultWorkflow(),
 		},
 		Templates: config.TemplateConfig{
 			Enabled: defaultTemplatesEnabled,
@@ -77,6 +83,7 @@ func CreateRexConfigFile() error {
 		},
 		EnableGithubPages: defaultEnabledGithubPages,
 		Pages: config.PagesConfig{
+			Path:  defaultPagesPath,
 			Index: defaultPagesIndex,
 			Web: config.PagesConfigWeb{
 				Config: defaultPagesWebConfig,
@@ -86,6 +93,9 @@ func CreateRexConfigFile() error {
 				},
 			},
 		},
+		Site: config.SiteConfig{
+			Path: defaultSitePath,
+		},
 		Extras: defaultExtras,
 		ExtraPages: config.ExtrasConfig{
 			Install: defaultExtraPagesInstall,
@@ -37,6 +37,7 @@ func defaultValueMap() map[string]any {
 		"defaultAdrPath":               "docs/adr/",
 		"defaultAdrIndexPage":          "README.md",
 		"defaultAdrAddToIndex":         true,
+		"defaultAdrIDWidth":            0,
 		"defaultTemplatesPath":         "templates/",
 		"defaultTemplatesEnabled":      false,
 		"defaultTemplatesAdrDefault":   "adr.tmpl",
@@ -141,6 +142,12 @@ func TestCreateRexConfigFile(t *testing.T) {
 				c.ADR.AddToIndex,
 				"ADR AddToIndex",
 			)
+			assert.Equal(
+				t,
+				test.defaultValues["defaultAdrIDWidth"],
+				c.ADR.IDWidth,
+				"ADR IDWidth",
+			)
 			// template
 			assert.Equal(
 				t,
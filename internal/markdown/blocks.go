@@ -0,0 +1,324 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import (
+	"strings"
+)
+
+// blockKind is the type of a markdown block.
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	headingBlock
+	ruleBlock
+	codeBlock
+	quoteBlock
+	listBlock
+	tableBlock
+)
+
+// block is a markdown block found by parseBlocks.
+//
+// Text is the text of a heading or paragraph, Level the level of a
+// heading and Info the info string of a code block. Lines holds the lines
+// of a code block, the lines inside a quote and the lines of a list, Rows
+// the cells of a table with the header first.
+type block struct {
+	Kind  blockKind
+	Level int
+	Text  string
+	Info  string
+	Lines []string
+	Rows  [][]string
+}
+
+// listEntry is an item of a list block.
+type listEntry struct {
+	Indent int
+	Marker string
+	Text   string
+}
+
+// Ordered reports if the item is part of a numbered list.
+func (e listEntry) Ordered() bool {
+	return !strings.ContainsAny(e.Marker, "-*+")
+}
+
+// parseBlocks splits the lines of a markdown document into blocks. Blank
+// lines separate blocks and are dropped, HTML comments are removed, see
+// stripComments.
+func parseBlocks(lines []string) []block {
+	lines = stripComments(lines)
+
+	var blocks []block
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, block{
+				Kind: paragraphBlock,
+				Text: strings.Join(paragraph, " "),
+			})
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case fenceLine.MatchString(line):
+			flush()
+			trimmed := strings.TrimSpace(line)
+			fence := trimmed[:3]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, block{
+				Kind:  codeBlock,
+				Info:  strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])),
+				Lines: code,
+			})
+
+		case headingLine.MatchString(line):
+			flush()
+			m := headingLine.FindStringSubmatch(line)
+			blocks = append(blocks, block{Kind: headingBlock, Level: len(m[1]), Text: m[2]})
+
+		case ruleLine.MatchString(line):
+			flush()
+			blocks = append(blocks, block{Kind: ruleBlock})
+
+		case quoteLine.MatchString(line):
+			flush()
+			var quote []string
+			for ; i < len(lines) && quoteLine.MatchString(lines[i]); i++ {
+				quote = append(quote, quoteLine.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			blocks = append(blocks, block{Kind: quoteBlock, Lines: quote})
+
+		case listItem.MatchString(line):
+			flush()
+			var list []string
+			for ; i < len(lines); i++ {
+				l := lines[i]
+				if strings.TrimSpace(l) == "" {
+					if i+1 < len(lines) && listItem.MatchString(lines[i+1]) {
+						continue
+					}
+					break
+				}
+				if !listItem.MatchString(l) && !strings.HasPrefix(l, " ") &&
+					!strings.HasPrefix(l, "\t") {
+					break
+				}
+				list = append(list, l)
+			}
+			i--
+			blocks = append(blocks, block{Kind: listBlock, Lines: list})
+
+		case IsTableRow(line) && i+1 < len(lines) && IsSeparatorRow(lines[i+1]):
+			flush()
+			rows := [][]string{TableCells(line)}
+			for i += 2; i < len(lines) && IsTableRow(lines[i]); i++ {
+				rows = append(rows, TableCells(lines[i]))
+			}
+			i--
+			blocks = append(blocks, block{Kind: tableBlock, Rows: rows})
+
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// stripComments removes the HTML comments from lines, leaving code blocks
+// as they are. A comment can span lines and be within a line of text, a
+// line left empty by removing a comment separates blocks like a blank
+// line.
+func stripComments(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	inComment := false
+	fence := ""
+
+	for _, line := range lines {
+		if fence != "" || (!inComment && fenceLine.MatchString(line)) {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case fence == "":
+				fence = trimmed[:3]
+			case strings.HasPrefix(trimmed, fence):
+				fence = ""
+			}
+			out = append(out, line)
+			continue
+		}
+
+		if !inComment && !strings.Contains(line, "<!--") {
+			out = append(out, line)
+			continue
+		}
+
+		var text strings.Builder
+		for rest := line; rest != ""; {
+			if inComment {
+				end := strings.Index(rest, "-->")
+				if end == -1 {
+					break
+				}
+				rest = rest[end+len("-->"):]
+				inComment = false
+
+				// don't leave a double space where the comment was
+				if strings.HasSuffix(text.String(), " ") {
+					rest = strings.TrimLeft(rest, " \t")
+				}
+				continue
+			}
+
+			start := strings.Index(rest, "<!--")
+			if start == -1 {
+				text.WriteString(rest)
+				break
+			}
+			text.WriteString(rest[:start])
+			rest = rest[start+len("<!--"):]
+			inComment = true
+		}
+
+		if strings.TrimSpace(text.String()) == "" {
+			out = append(out, "")
+		} else {
+			out = append(out, text.String())
+		}
+	}
+
+	return out
+}
+
+// listEntries splits the lines of a list block into its items, joining
+// continuation lines onto the item they follow.
+func listEntries(lines []string) []listEntry {
+	var entries []listEntry
+	for _, l := range lines {
+		if m := listItem.FindStringSubmatch(l); m != nil {
+			entries = append(entries, listEntry{
+				Indent: len(strings.ReplaceAll(m[1], "\t", "    ")),
+				Marker: m[2],
+				Text:   m[3],
+			})
+			continue
+		}
+		if len(entries) > 0 {
+			entries[len(entries)-1].Text += " " + strings.TrimSpace(l)
+		}
+	}
+	return entries
+}
+
+// splitLines splits src into lines, normalising line endings.
+func splitLines(src string) []string {
+	return strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+}
+
+// inlineFormat renders the parts of inline markdown found by renderInline.
+// Text has already been rendered when it is passed to link, strong and
+// emphasis.
+type inlineFormat interface {
+	plain(text string) string
+	codeSpan(code string) string
+	link(image bool, text, dest string) string
+	strong(text string) string
+	emphasis(text string) string
+}
+
+// renderInline renders the emphasis, code and links within text with f.
+func renderInline(text string, f inlineFormat) string {
+	var b strings.Builder
+	var plain strings.Builder
+
+	write := func(s string) {
+		b.WriteString(f.plain(plain.String()))
+		plain.Reset()
+		b.WriteString(s)
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && isPunct(text[i+1]):
+			plain.WriteByte(text[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end != -1 {
+				write(f.codeSpan(rest[1 : end+1]))
+				i += end + 2
+				continue
+			}
+
+		case c == '[' || (c == '!' && strings.HasPrefix(rest, "![")):
+			if m := linkSpan.FindStringSubmatch(rest); m != nil {
+				write(f.link(m[1] == "!", renderInline(m[2], f), m[3]))
+				i += len(m[0])
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := strings.Index(rest[2:], rest[:2]); end > 0 {
+				write(f.strong(renderInline(rest[2:end+2], f)))
+				i += end + 4
+				continue
+			}
+
+		case (c == '*' || c == '_') && (i == 0 || !isWord(text[i-1])):
+			if end := strings.IndexByte(rest[1:], c); end > 0 &&
+				(end+2 >= len(rest) || !isWord(rest[end+2])) {
+				write(f.emphasis(renderInline(rest[1:end+1], f)))
+				i += end + 2
+				continue
+			}
+		}
+
+		plain.WriteByte(c)
+		i++
+	}
+	write("")
+
+	return b.String()
+}
@@ -0,0 +1,217 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// HTMLRenderer renders markdown as HTML.
+//
+// Link, if set, is called with the destination of every link and image and
+// returns the destination to use, IE: to point links to other markdown files
+// at the pages generated from them.
+type HTMLRenderer struct {
+	Link func(dest string) string
+
+	ids map[string]int
+}
+
+// Render returns src rendered as HTML. Headings are given an id made from
+// their text so they can be linked to.
+func (h *HTMLRenderer) Render(src string) string {
+	h.ids = map[string]int{}
+
+	blocks := h.blocks(splitLines(src))
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n") + "\n"
+}
+
+// blocks renders lines as a list of HTML blocks.
+func (h *HTMLRenderer) blocks(lines []string) []string {
+	var out []string
+	for _, b := range parseBlocks(lines) {
+		switch b.Kind {
+		case headingBlock:
+			text := h.inline(b.Text)
+			out = append(out, fmt.Sprintf(
+				"<h%d id=\"%s\">%s</h%d>",
+				b.Level,
+				h.headingID(b.Text),
+				text,
+				b.Level,
+			))
+		case ruleBlock:
+			out = append(out, "<hr>")
+		case codeBlock:
+			out = append(out, h.code(b.Info, b.Lines))
+		case quoteBlock:
+			out = append(out, "<blockquote>\n"+strings.Join(h.blocks(b.Lines), "\n")+"\n</blockquote>")
+		case listBlock:
+			out = append(out, h.list(listEntries(b.Lines)))
+		case tableBlock:
+			out = append(out, h.table(b.Rows))
+		default:
+			out = append(out, "<p>"+h.inline(b.Text)+"</p>")
+		}
+	}
+	return out
+}
+
+// code renders a code block, the first word of info being its language.
+func (h *HTMLRenderer) code(info string, lines []string) string {
+	class := ""
+	if lang, _, _ := strings.Cut(info, " "); lang != "" {
+		class = fmt.Sprintf(" class=\"language-%s\"", html.EscapeString(lang))
+	}
+
+	code := html.EscapeString(strings.Join(lines, "\n"))
+	if code != "" {
+		code += "\n"
+	}
+	return fmt.Sprintf("<pre><code%s>%s</code></pre>", class, code)
+}
+
+// list renders the entries of a list, entries indented further than the
+// entry before them are nested in it.
+func (h *HTMLRenderer) list(entries []listEntry) string {
+	var b strings.Builder
+	h.writeList(&b, entries)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeList writes entries as a list, returning the number of entries
+// written. The list ends at the first entry indented less than the first.
+func (h *HTMLRenderer) writeList(b *strings.Builder, entries []listEntry) int {
+	tag := "ul"
+	if entries[0].Ordered() {
+		tag = "ol"
+	}
+	indent := entries[0].Indent
+
+	fmt.Fprintf(b, "<%s>\n", tag)
+	i := 0
+	for i < len(entries) && entries[i].Indent >= indent {
+		b.WriteString("<li>" + h.inline(entries[i].Text))
+		i++
+		if i < len(entries) && entries[i].Indent > indent {
+			b.WriteString("\n")
+			i += h.writeList(b, entries[i:])
+		}
+		b.WriteString("</li>\n")
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+
+	return i
+}
+
+// table renders rows as a table, the first row being the header.
+func (h *HTMLRenderer) table(rows [][]string) string {
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, c := range rows[0] {
+		b.WriteString("<th>" + h.inline(c) + "</th>")
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range rows[1:] {
+		b.WriteString("<tr>")
+		for _, c := range row {
+			b.WriteString("<td>" + h.inline(c) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>")
+	return b.String()
+}
+
+// headingID returns a unique id for a heading made from its text,
+// IE: "Decision Outcome" = "decision-outcome".
+func (h *HTMLRenderer) headingID(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	id := b.String()
+	if id == "" {
+		id = "section"
+	}
+	if n := h.ids[id]; n > 0 {
+		h.ids[id]++
+		return fmt.Sprintf("%s-%d", id, n)
+	}
+	h.ids[id]++
+	return id
+}
+
+// inline renders the emphasis, code and links within text.
+func (h *HTMLRenderer) inline(text string) string {
+	return renderInline(text, h)
+}
+
+// plain escapes text.
+func (h *HTMLRenderer) plain(text string) string {
+	return html.EscapeString(text)
+}
+
+// codeSpan renders inline code.
+func (h *HTMLRenderer) codeSpan(code string) string {
+	return "<code>" + html.EscapeString(code) + "</code>"
+}
+
+// link renders a link, or an image with text as its alt text.
+func (h *HTMLRenderer) link(image bool, text, dest string) string {
+	if h.Link != nil {
+		dest = h.Link(dest)
+	}
+	dest = html.EscapeString(dest)
+
+	if image {
+		return fmt.Sprintf("<img src=\"%s\" alt=\"%s\">", dest, text)
+	}
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", dest, text)
+}
+
+// strong renders bold text.
+func (h *HTMLRenderer) strong(text string) string {
+	return "<strong>" + text + "</strong>"
+}
+
+// emphasis renders italic text.
+func (h *HTMLRenderer) emphasis(text string) string {
+	return "<em>" + text + "</em>"
+}
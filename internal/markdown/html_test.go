@@ -0,0 +1,90 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLRender(t *testing.T) {
+	tests := map[string]struct {
+		src      string
+		link     func(string) string
+		expected string
+	}{
+		"empty": {
+			src:      "\n<!-- comment -->\n",
+			expected: "",
+		},
+		"comments": {
+			src:      "before <!-- inline --> after\n<!--\nmulti line\n-->\n- <!-- placeholder -->\n```\n<!-- code -->\n```\n",
+			expected: "<p>before after</p>\n<ul>\n<li></li>\n</ul>\n<pre><code>&lt;!-- code --&gt;\n</code></pre>\n",
+		},
+		"headings": {
+			src:      "# Use Go\n## Decision Outcome\n## Decision Outcome\n### C++ & Go ###\n",
+			expected: "<h1 id=\"use-go\">Use Go</h1>\n<h2 id=\"decision-outcome\">Decision Outcome</h2>\n<h2 id=\"decision-outcome-1\">Decision Outcome</h2>\n<h3 id=\"c-go\">C++ &amp; Go</h3>\n",
+		},
+		"paragraph": {
+			src:      "one <two>\nthree & four\n\nfive\n",
+			expected: "<p>one &lt;two&gt; three &amp; four</p>\n<p>five</p>\n",
+		},
+		"inline": {
+			src:      "**bold** *italic* _also_ `a<b` snake_case \\*lit\\*\n",
+			expected: "<p><strong>bold</strong> <em>italic</em> <em>also</em> <code>a&lt;b</code> snake_case *lit*</p>\n",
+		},
+		"links": {
+			src:      "[ADR 2](2-use-go.md) [docs](https://example.com) ![diagram](d.png)\n",
+			link:     func(dest string) string { return strings.TrimSuffix(dest, ".md") + ".html" },
+			expected: "<p><a href=\"2-use-go.html\">ADR 2</a> <a href=\"https://example.com.html\">docs</a> <img src=\"d.png.html\" alt=\"diagram\"></p>\n",
+		},
+		"list": {
+			src:      "- one\n  continued\n- two\n  1. nested\n  2. again\n- three\n",
+			expected: "<ul>\n<li>one continued</li>\n<li>two\n<ol>\n<li>nested</li>\n<li>again</li>\n</ol>\n</li>\n<li>three</li>\n</ul>\n",
+		},
+		"quote": {
+			src:      "> quoted **text**\n> - item\n",
+			expected: "<blockquote>\n<p>quoted <strong>text</strong></p>\n<ul>\n<li>item</li>\n</ul>\n</blockquote>\n",
+		},
+		"code": {
+			src:      "```go\nif a < b {\n}\n```\n",
+			expected: "<pre><code class=\"language-go\">if a &lt; b {\n}\n</code></pre>\n",
+		},
+		"table": {
+			src:      "| Status | Author |\n| ------ | ------ |\n| Draft | *Jane* |\n",
+			expected: "<table>\n<thead>\n<tr><th>Status</th><th>Author</th></tr>\n</thead>\n<tbody>\n<tr><td>Draft</td><td><em>Jane</em></td></tr>\n</tbody>\n</table>\n",
+		},
+		"rule": {
+			src:      "---\n",
+			expected: "<hr>\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := &HTMLRenderer{Link: test.link}
+			assert.Equal(t, test.expected, h.Render(test.src), "")
+		})
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package markdown renders markdown documents as text for the terminal or
+// as HTML.
+//
+// Only the markdown used in ADR's is handled: headings, paragraphs, lists,
+// block quotes, code blocks, tables, rules and inline emphasis, code and
+// links. Anything else is printed as it was written.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI escape codes used when Color is set.
+const (
+	reset     = "\x1b[0m"
+	bold      = "\x1b[1m"
+	faint     = "\x1b[2m"
+	italic    = "\x1b[3m"
+	underline = "\x1b[4m"
+	cyan      = "\x1b[36m"
+	magenta   = "\x1b[35m"
+)
+
+// DefaultWidth is the width text is wrapped at when Width is not set.
+const DefaultWidth = 80
+
+var (
+	headingLine = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleLine    = regexp.MustCompile(`^\s{0,3}(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	fenceLine   = regexp.MustCompile("^\\s{0,3}(```+|~~~+)")
+	listItem    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	quoteLine   = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	linkSpan    = regexp.MustCompile(`^(!?)\[([^\]]*)\]\(([^)\s]*)(?:\s+"[^"]*")?\)`)
+	ansiCode    = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// Renderer renders markdown for the terminal.
+//
+// Width is the column text is wrapped at, 0 uses DefaultWidth. If Color is
+// set ANSI escape codes are used for emphasis, otherwise plain text is
+// written.
+type Renderer struct {
+	Width int
+	Color bool
+}
+
+// Render returns src rendered as text.
+func (r *Renderer) Render(src string) string {
+	width := r.Width
+	if width <= 0 {
+		width = DefaultWidth
+	}
+
+	blocks := r.blocks(splitLines(src), width)
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// blocks renders lines as a list of blocks wrapped to width.
+func (r *Renderer) blocks(lines []string, width int) []string {
+	var out []string
+	for _, b := range parseBlocks(lines) {
+		switch b.Kind {
+		case headingBlock:
+			out = append(out, r.heading(b.Level, b.Text))
+		case ruleBlock:
+			out = append(out, r.rule(width))
+		case codeBlock:
+			out = append(out, r.code(b.Lines))
+		case quoteBlock:
+			out = append(out, r.quote(b.Lines, width))
+		case listBlock:
+			out = append(out, r.list(b.Lines, width))
+		case tableBlock:
+			out = append(out, r.table(b.Rows))
+		default:
+			out = append(out, strings.Join(wrap(r.inline(b.Text), width), "\n"))
+		}
+	}
+	return out
+}
+
+// heading renders a heading of the given level.
+func (r *Renderer) heading(level int, text string) string {
+	text = r.inline(text)
+	if r.Color {
+		switch level {
+		case 1:
+			return bold + underline + magenta + text + reset
+		case 2:
+			return bold + magenta + text + reset
+		default:
+			return bold + text + reset
+		}
+	}
+
+	switch level {
+	case 1:
+		return text + "\n" + strings.Repeat("=", visibleWidth(text))
+	case 2:
+		return text + "\n" + strings.Repeat("-", visibleWidth(text))
+	default:
+		return strings.Repeat("#", level) + " " + text
+	}
+}
+
+// rule renders a horizontal rule across width.
+func (r *Renderer) rule(width int) string {
+	if r.Color {
+		return faint + strings.Repeat("─", width) + reset
+	}
+	return strings.Repeat("-", width)
+}
+
+// code renders the lines of a code block indented and unwrapped.
+func (r *Renderer) code(lines []string) string {
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		l = "    " + strings.ReplaceAll(l, "\t", "    ")
+		if r.Color {
+			l = cyan + l + reset
+		}
+		out = append(out, strings.TrimRight(l, " "))
+	}
+	return strings.Join(out, "\n")
+}
+
+// quote renders the lines of a block quote with a bar in front of them.
+func (r *Renderer) quote(lines []string, width int) string {
+	bar := "> "
+	if r.Color {
+		bar = faint + "│ " + reset
+	}
+
+	inner := strings.Join(r.blocks(lines, width-2), "\n\n")
+	out := strings.Split(inner, "\n")
+	for i, l := range out {
+		out[i] = strings.TrimRight(bar+l, " ")
+	}
+	return strings.Join(out, "\n")
+}
+
+// list renders the lines of a list, wrapping each item beneath its text.
+func (r *Renderer) list(lines []string, width int) string {
+	var out []string
+	for _, e := range listEntries(lines) {
+		indent := strings.Repeat(" ", e.Indent)
+		hanging := strings.Repeat(" ", utf8.RuneCountInString(indent+e.Marker+" "))
+		for i, l := range wrap(r.inline(e.Text), width-len(hanging)) {
+			if i == 0 {
+				out = append(out, r.bullet(indent, e.Marker)+" "+l)
+				continue
+			}
+			out = append(out, hanging+l)
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// bullet renders the marker of a list item.
+func (r *Renderer) bullet(indent, marker string) string {
+	if !r.Color {
+		if strings.ContainsAny(marker, "*+") {
+			marker = "-"
+		}
+		return indent + marker
+	}
+	if strings.ContainsAny(marker, "-*+") {
+		marker = "•"
+	}
+	return indent + magenta + marker + reset
+}
+
+// table renders rows with their columns aligned, the first row being the
+// header.
+func (r *Renderer) table(rows [][]string) string {
+	var widths []int
+	for i, row := range rows {
+		for j, cell := range row {
+			row[j] = r.inline(cell)
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], visibleWidth(row[j]))
+		}
+		rows[i] = row
+	}
+
+	var out []string
+	for i, row := range rows {
+		cells := make([]string, 0, len(widths))
+		for j, w := range widths {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			pad := strings.Repeat(" ", w-visibleWidth(cell))
+			if i == 0 && r.Color {
+				cell = bold + cell + reset
+			}
+			cells = append(cells, cell+pad)
+		}
+		out = append(out, strings.TrimRight(strings.Join(cells, "  "), " "))
+
+		if i == 0 {
+			rules := make([]string, 0, len(widths))
+			for _, w := range widths {
+				rules = append(rules, strings.Repeat("-", w))
+			}
+			out = append(out, strings.Join(rules, "  "))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// inline renders the emphasis, code and links within text.
+func (r *Renderer) inline(text string) string {
+	return renderInline(text, r)
+}
+
+// plain returns text as it is, the terminal has nothing to escape.
+func (r *Renderer) plain(text string) string {
+	return text
+}
+
+// codeSpan renders inline code.
+func (r *Renderer) codeSpan(code string) string {
+	return r.style(cyan, code)
+}
+
+// strong renders bold text.
+func (r *Renderer) strong(text string) string {
+	return r.style(bold, text)
+}
+
+// emphasis renders italic text.
+func (r *Renderer) emphasis(text string) string {
+	return r.style(italic, text)
+}
+
+// link renders a link as its text followed by its destination. Images are
+// shown by their alt text.
+func (r *Renderer) link(image bool, text, dest string) string {
+	if image {
+		return "[image: " + text + "]"
+	}
+	if text == "" || text == dest {
+		return r.style(underline, dest)
+	}
+	return r.style(underline, text) + " " + r.style(faint, "("+dest+")")
+}
+
+// style wraps text in the ANSI code when Color is set.
+func (r *Renderer) style(code, text string) string {
+	if !r.Color {
+		return text
+	}
+	return code + text + reset
+}
+
+// wrap splits text into lines no wider than width, breaking on spaces.
+// Words longer than width are kept on their own line.
+func wrap(text string, width int) []string {
+	width = max(width, 1)
+
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case visibleWidth(line)+1+visibleWidth(word) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// visibleWidth returns the number of characters shown for s, ignoring ANSI
+// escape codes.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiCode.ReplaceAllString(s, ""))
+}
+
+// isPunct reports if c is an ASCII punctuation character that can be
+// escaped with a backslash.
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) != -1
+}
+
+// isWord reports if c is an ASCII letter or digit.
+func isWord(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := map[string]struct {
+		src      string
+		width    int
+		expected string
+	}{
+		"empty": {
+			src:      "\n\n",
+			expected: "",
+		},
+		"headings": {
+			src:      "# Title\n## Context\n### Options ###\n",
+			expected: "Title\n=====\n\nContext\n-------\n\n### Options\n",
+		},
+		"paragraph_wraps": {
+			src:      "one two three\nfour five six seven\n",
+			width:    10,
+			expected: "one two\nthree four\nfive six\nseven\n",
+		},
+		"inline": {
+			src:      "**bold** *italic* _also_ `co*de` snake_case \\*lit\\*\n",
+			expected: "bold italic also co*de snake_case *lit*\n",
+		},
+		"links": {
+			src:      "[docs](https://example.com) <https://x.y> [https://a.b](https://a.b) ![diagram](d.png)\n",
+			expected: "docs (https://example.com) <https://x.y> https://a.b [image: diagram]\n",
+		},
+		"list": {
+			src:      "- one two three four\n* two\n  - nested\n1. first\n",
+			width:    12,
+			expected: "- one two\n  three four\n- two\n  - nested\n1. first\n",
+		},
+		"list_after_paragraph": {
+			src:      "Options:\n- one\n- two\n",
+			expected: "Options:\n\n- one\n- two\n",
+		},
+		"quote": {
+			src:      "> quoted\n> text\n",
+			expected: "> quoted text\n",
+		},
+		"code": {
+			src:      "```go\nfunc main() {\n\treturn\n}\n```\n",
+			expected: "    func main() {\n        return\n    }\n",
+		},
+		"table": {
+			src:      "| ID | Title |\n| -- | ----- |\n| 1 | **Use Go** |\n| 10 | Use Postgres |\n",
+			expected: "ID  Title\n--  ------------\n1   Use Go\n10  Use Postgres\n",
+		},
+		"rule_and_comment": {
+			src:      "above\n\n---\n<!-- rex:index:start -->\nbelow\n",
+			width:    5,
+			expected: "above\n\n-----\n\nbelow\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &Renderer{Width: test.width}
+			assert.Equal(t, test.expected, r.Render(test.src), "")
+		})
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	r := &Renderer{Width: 20, Color: true}
+
+	assert.Equal(
+		t,
+		"\x1b[1m\x1b[4m\x1b[35mTitle\x1b[0m\n\n\x1b[1mbold\x1b[0m and \x1b[36mcode\x1b[0m\n",
+		r.Render("# Title\n\n**bold** and `code`\n"),
+		"",
+	)
+
+	// escape codes don't count towards the width
+	assert.Equal(
+		t,
+		"\x1b[1maaaa\x1b[0m \x1b[1mbbbb\x1b[0m\n\x1b[1mcccc\x1b[0m\n",
+		(&Renderer{Width: 10, Color: true}).Render("**aaaa** **bbbb** **cccc**"),
+		"",
+	)
+}
+
+func TestWrap(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		width    int
+		expected []string
+	}{
+		"fits":      {text: "a b c", width: 10, expected: []string{"a b c"}},
+		"breaks":    {text: "aa bb cc", width: 5, expected: []string{"aa bb", "cc"}},
+		"long_word": {text: "a abcdefgh b", width: 4, expected: []string{"a", "abcdefgh", "b"}},
+		"empty":     {text: " ", width: 4, expected: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, wrap(test.text, test.width), "")
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import "strings"
+
+// IsTableRow reports if the line is a markdown table row.
+func IsTableRow(line string) bool {
+	l := strings.TrimSpace(line)
+	return len(l) > 1 && strings.HasPrefix(l, "|") && strings.HasSuffix(l, "|")
+}
+
+// IsSeparatorRow reports if the line separates a table header from its rows.
+func IsSeparatorRow(line string) bool {
+	if !IsTableRow(line) {
+		return false
+	}
+	for _, c := range TableCells(line) {
+		if strings.Trim(c, "-: ") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// TableCells splits a markdown table row into its trimmed cells.
+func TableCells(line string) []string {
+	l := strings.TrimSpace(line)
+	l = strings.TrimSuffix(strings.TrimPrefix(l, "|"), "|")
+
+	cells := strings.Split(l, "|")
+	for i, c := range cells {
+		cells[i] = strings.TrimSpace(c)
+	}
+	return cells
+}
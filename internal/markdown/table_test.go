@@ -0,0 +1,73 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableRows(t *testing.T) {
+	tests := map[string]struct {
+		line      string
+		row       bool
+		separator bool
+		cells     []string
+	}{
+		"row": {
+			line:  "| Status | Author |",
+			row:   true,
+			cells: []string{"Status", "Author"},
+		},
+		"separator": {
+			line:      " | :----- | ------: |",
+			row:       true,
+			separator: true,
+			cells:     []string{":-----", "------:"},
+		},
+		"empty_cell": {
+			line:  "| Draft |  |",
+			row:   true,
+			cells: []string{"Draft", ""},
+		},
+		"pipe": {
+			line: "|",
+		},
+		"rule": {
+			line: "---",
+		},
+		"blank": {
+			line: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.row, IsTableRow(test.line), "")
+			assert.Equal(t, test.separator, IsSeparatorRow(test.line), "")
+			if test.row {
+				assert.Equal(t, test.cells, TableCells(test.line), "")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// textFormat renders inline markdown as its text, without the emphasis,
+// code spans or link destinations.
+type textFormat struct{}
+
+func (textFormat) plain(text string) string    { return text }
+func (textFormat) codeSpan(code string) string { return code }
+func (textFormat) strong(text string) string   { return text }
+func (textFormat) emphasis(text string) string { return text }
+
+func (textFormat) link(_ bool, text, _ string) string { return text }
+
+// Text returns the text of the markdown in src without its formatting,
+// with a line for every heading, paragraph, list item, table row and code
+// line.
+func Text(src string) string {
+	return strings.Join(textBlocks(splitLines(src)), "\n")
+}
+
+func textBlocks(lines []string) []string {
+	var out []string
+	for _, b := range parseBlocks(lines) {
+		switch b.Kind {
+		case headingBlock, paragraphBlock:
+			out = append(out, renderInline(b.Text, textFormat{}))
+		case codeBlock:
+			out = append(out, b.Lines...)
+		case quoteBlock:
+			out = append(out, textBlocks(b.Lines)...)
+		case listBlock:
+			for _, e := range listEntries(b.Lines) {
+				out = append(out, renderInline(e.Text, textFormat{}))
+			}
+		case tableBlock:
+			for _, row := range b.Rows {
+				cells := make([]string, 0, len(row))
+				for _, c := range row {
+					cells = append(cells, renderInline(c, textFormat{}))
+				}
+				out = append(out, strings.Join(cells, " "))
+			}
+		}
+	}
+	return out
+}
+
+// Summary returns the text of the first paragraph in src, shortened to at
+// most n characters at the end of a word with an ellipsis added. Returns
+// an empty string if src has no paragraphs.
+func Summary(src string, n int) string {
+	for _, b := range parseBlocks(splitLines(src)) {
+		if b.Kind != paragraphBlock {
+			continue
+		}
+
+		text := renderInline(b.Text, textFormat{})
+		if utf8.RuneCountInString(text) <= n {
+			return text
+		}
+
+		cut := string([]rune(text)[:n])
+		if i := strings.LastIndexByte(cut, ' '); i > 0 {
+			cut = cut[:i]
+		}
+		return strings.TrimRight(cut, " ,.;:") + "…"
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	tests := map[string]struct {
+		src      string
+		expected string
+	}{
+		"empty": {
+			src:      "\n<!-- comment -->\n",
+			expected: "",
+		},
+		"blocks": {
+			src:      "## Context\n\nWe **need** a `cache`\nfor [sessions](2-cache.md).\n\n---\n\n> quoted *text*\n",
+			expected: "Context\nWe need a cache for sessions.\nquoted text",
+		},
+		"list": {
+			src:      "- one\n  continued\n  1. nested\n",
+			expected: "one continued\nnested",
+		},
+		"table": {
+			src:      "| Option | Cost |\n| --- | --- |\n| **Redis** | low |\n",
+			expected: "Option Cost\nRedis low",
+		},
+		"code": {
+			src:      "```go\nfunc main() {}\n```\n",
+			expected: "func main() {}",
+		},
+		"image": {
+			src:      "![the diagram](d.png)\n",
+			expected: "the diagram",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Text(test.src), "")
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tests := map[string]struct {
+		src      string
+		n        int
+		expected string
+	}{
+		"first paragraph": {
+			src:      "## Context\n\nWe need a **cache**.\n\nSecond paragraph.\n",
+			n:        100,
+			expected: "We need a cache.",
+		},
+		"shortened": {
+			src:      "We need a cache for sessions, users and more.\n",
+			n:        30,
+			expected: "We need a cache for sessions…",
+		},
+		"no space": {
+			src:      "Supercalifragilistic\n",
+			n:        5,
+			expected: "Super…",
+		},
+		"multibyte": {
+			src:      "Ünïcödé wörds hërë\n",
+			n:        10,
+			expected: "Ünïcödé…",
+		},
+		"no paragraph": {
+			src:      "# Title\n\n- item\n",
+			n:        10,
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Summary(test.src, test.n), "")
+		})
+	}
+}
@@ -25,8 +25,15 @@ THE SOFTWARE.
 package rex
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/donaldgifford/rex/internal/adr"
 	"github.com/donaldgifford/rex/internal/config"
+	"github.com/donaldgifford/rex/internal/serve"
 	"github.com/donaldgifford/rex/internal/templates"
 )
 
@@ -57,25 +64,115 @@ func (r *Rex) Settings() *config.RexConfig {
 	return r.Config.Settings()
 }
 
-// NewADR creates a new ADR from content on disk and updates
-// the current index configured.
-func (r *Rex) NewADR(content *adr.Content) error {
+// NewADR creates a new ADR from content on disk and returns the path of
+// the file written. The ADR is written with the template named in
+// content, or the default template.
+//
+// force: if an ADR file with the same name exists, this option will
+// overwrite it.
+func (r *Rex) NewADR(content *adr.Content, force bool) (string, error) {
+	// check the template exists before an id is used
+	err := templates.CheckName(r.Template.GetSettings(), content.Template)
+	if err != nil {
+		return "", err
+	}
+
 	// create adr
-	adr, err := r.ADR.Create(content)
+	adr, reservation, err := r.ADR.Create(content)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// write ADR to disk using template
-	err = r.Template.CreateADR(adr)
+	file, err := r.Template.CreateADR(adr, force)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	// the reservation is only removed once the ADR is on disk
+	err = r.ADR.Claim(reservation)
+	if err != nil {
+		return file, fmt.Errorf("%s was written but its reservation couldn't be removed: %w", file, err)
+	}
+
+	return file, nil
+}
+
+// ValidateADR checks the ADR file can be parsed and its metadata is valid.
+func (r *Rex) ValidateADR(file string) error {
+	return adr.Validate(file)
+}
+
+// ReviseADR creates a new revision of the ADR with the given id, recording
+// note in its revision history.
+func (r *Rex) ReviseADR(id int, note string) (*adr.ADR, error) {
+	return r.ADR.Revision(id, note)
 }
 
+// SetStatus changes the status of the ADR with the given id. Unless
+// force is set the change must be allowed by the configured workflow.
+func (r *Rex) SetStatus(id int, status string, force bool) (*adr.ADR, error) {
+	return r.ADR.SetStatus(id, status, force)
+}
+
+// Relate records relation from the ADR with id to the ADR with id other,
+// and the inverse relation on other.
+func (r *Rex) Relate(id int, relation string, other int) error {
+	return r.ADR.Relate(id, relation, other)
+}
+
+// Supersede records that the ADR with id by supersedes the ADR with id old
+// and marks old as Superseded.
+func (r *Rex) Supersede(old, by int, force bool) (*adr.ADR, error) {
+	return r.ADR.Supersede(old, by, force)
+}
+
+// Renumber gives ADR's sharing an id a new id, updating the relations
+// linking to them. If dryRun is set nothing is changed.
+func (r *Rex) Renumber(dryRun bool) ([]adr.Renumbered, error) {
+	return r.ADR.Renumber(dryRun)
+}
+
+// Reserve claims the next ADR id for an ADR with title.
+func (r *Rex) Reserve(title, author, date string) (*adr.Reservation, error) {
+	return r.ADR.Reserve(title, author, date)
+}
+
+// FindADR returns the ADR ref points to, either its id or the slug of its
+// file name or title.
+func (r *Rex) FindADR(ref string) (*adr.ADR, error) {
+	return r.ADR.Find(ref)
+}
+
+// Search returns the ADR's matching query, the best match first. See
+// adr.ParseQuery for the query syntax.
+func (r *Rex) Search(query string) ([]adr.SearchResult, error) {
+	return r.ADR.Search(query)
+}
+
+// ListADRs returns the ADR's on disk filtered by status and author.
+// Empty filters return every ADR. ADR files that can't be parsed are left
+// out and returned in an *adr.SkippedError with the other ADR's.
+func (r *Rex) ListADRs(status, author string) ([]*adr.ADR, error) {
+	adrs, err := r.ADR.List()
+	var skipped *adr.SkippedError
+	if err != nil && !errors.As(err, &skipped) {
+		return nil, err
+	}
+
+	return adr.Filter(adrs, status, author), err
+}
+
+// UpdateIndex reads the ADR's on disk and updates the index with them.
+// Nothing is done if "adr.add_to_index" is false.
+//
+// force: if an index without a managed region already exists, this option
+// will overwrite it.
 func (r *Rex) UpdateIndex(force bool) error {
+	if !r.Settings().ADR.AddToIndex {
+		return nil
+	}
+
 	err := r.Index.ADRs()
 	if err != nil {
 		return err
@@ -89,6 +186,91 @@ func (r *Rex) UpdateIndex(force bool) error {
 	return nil
 }
 
+// GeneratePages writes a Jekyll site for GitHub Pages with a page for
+// every ADR and returns the files written. The site is written to path, or
+// "pages.path" if path is empty. Returns an error if "enable_github_pages"
+// is false.
+//
+// force: overwrite the Jekyll config and layouts if they already exist.
+func (r *Rex) GeneratePages(path string, force bool) ([]string, error) {
+	if !r.Settings().EnableGithubPages {
+		return nil, errors.New(
+			"GitHub Pages are disabled, set enable_github_pages: true in your .rex.yaml",
+		)
+	}
+
+	site := templates.NewSite()
+	if path != "" {
+		site.Path = path
+	}
+
+	var err error
+	site.ADRIndex, site.ADRs, err = r.siteADRs()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Template.GeneratePages(site, force)
+}
+
+// BuildSite writes a static HTML site with a page for every ADR and
+// returns the files written. The site is written to path, or "site.path"
+// if path is empty.
+func (r *Rex) BuildSite(path string) ([]string, error) {
+	site := templates.NewStaticSite()
+	if path != "" {
+		site.Path = path
+	}
+
+	var err error
+	site.ADRIndex, site.ADRs, err = r.siteADRs()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Template.BuildSite(site)
+}
+
+// Serve builds the static HTML site to a temporary directory, serves it on
+// addr and rebuilds it, reloading the browser, when an ADR or template
+// changes until ctx is done. Builds and errors are logged to out.
+func (r *Rex) Serve(ctx context.Context, addr string, out io.Writer) error {
+	dir, err := os.MkdirTemp("", "rex-site-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	watch := []string{r.Settings().ADR.Path}
+	if r.Settings().Templates.Enabled {
+		watch = append(watch, r.Settings().Templates.Path)
+	}
+
+	s := serve.New(dir, watch, func() error {
+		_, err := r.BuildSite(dir)
+		return err
+	})
+	s.Log = out
+
+	return s.ListenAndServe(ctx, addr)
+}
+
+// siteADRs returns the ADR index and the ADR's on disk, ordered by id,
+// that sites are generated from.
+func (r *Rex) siteADRs() (*adr.Index, []*adr.ADR, error) {
+	err := r.Index.ADRs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adrs, err := r.ADR.List()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.Index.Execute(), adrs, nil
+}
+
 // GenerateDirectories creates the default directories used for rex
 // force is used to overwrite the templates if found
 //
@@ -114,12 +296,18 @@ func (r *Rex) GenerateTemplates(force bool) error {
 	return nil
 }
 
-// GenerateIndex updates the current index
+// GenerateIndex updates the current index with the ADR's found in
+// "adr.path"
 //
 // if force is set, it will overwrite the current index file if
 // found.
 func (r *Rex) GenerateIndex(force bool) error {
-	err := r.Template.GenerateIndex(r.Index.Execute(), force)
+	err := r.Index.ADRs()
+	if err != nil {
+		return err
+	}
+
+	err = r.Template.GenerateIndex(r.Index.Execute(), force)
 	if err != nil {
 		return err
 	}
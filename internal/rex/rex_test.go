@@ -22,9 +22,12 @@ THE SOFTWARE.
 package rex
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -272,7 +275,7 @@ func TestRexNewAdr(t *testing.T) {
 		}
 
 		r := New()
-		err := r.NewADR(&u)
+		_, err := r.NewADR(&u, false)
 
 		t.Run(name, func(t *testing.T) {
 			if test.err {
@@ -284,7 +287,383 @@ func TestRexNewAdr(t *testing.T) {
 	}
 }
 
+func TestRexNewADRReservation(t *testing.T) {
+	adrPath := "tests/reserve/docs/adr/"
+	reservations := "tests/reserve/reservations.yaml"
+	err := createTestFolder(adrPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("adr.path", adrPath)
+	viper.Set("adr.reservations", reservations)
+	defer viper.Set("adr.reservations", "")
+
+	r := New()
+	res, err := r.Reserve("Use Redis", "Author", "2025-01-05")
+	assert.Nil(t, err, "")
+
+	// an ADR file in the way makes writing the ADR fail
+	fileName, err := r.ADR.GetSettings().FileName(res.ID, res.Title)
+	assert.Nil(t, err, "")
+	err = os.WriteFile(adrPath+fileName, []byte("# Use Redis\n"), 0o644)
+	assert.Nil(t, err, "")
+
+	content := adr.Content{Title: "Use Redis", Author: "Author", Status: "Draft", Date: "2025-01-05"}
+	_, err = New().NewADR(&content, false)
+	assert.Error(t, err, "")
+
+	b, err := os.ReadFile(reservations)
+	assert.Nil(t, err, "")
+	assert.Contains(t, string(b), "title: Use Redis", "the reservation is kept when the ADR isn't written")
+
+	file, err := New().NewADR(&content, true)
+	assert.Nil(t, err, "")
+	assert.Equal(t, adrPath+fileName, file, "")
+
+	b, err = os.ReadFile(reservations)
+	assert.Nil(t, err, "")
+	assert.Equal(t, "[]\n", string(b), "")
+}
+
+func TestRexReviseADR(t *testing.T) {
+	revisionPath := "tests/revision/docs/adr/"
+	err := createTestFolder(revisionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(
+		revisionPath+"1-Revision.md",
+		[]byte("# Revision\n\n| Status | Author |  Created | Last Update | Current Version |\n| ------ | ------ | -------- | ----------- | --------------- |\n| Draft | Author | 2025-01-05 | N/A | v0.1.0 |\n"),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		configPath string
+		id         int
+		version    string
+		err        bool
+	}{
+		"good": {
+			configPath: revisionPath,
+			id:         1,
+			version:    "v0.1.1",
+			err:        false,
+		},
+		"error": {
+			configPath: "/path/to/adr",
+			id:         1,
+			err:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.path", test.configPath)
+
+			r := New()
+			a, err := r.ReviseADR(test.id, "note")
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+				assert.Equal(t, test.version, a.Content.Version, "")
+			}
+		})
+	}
+}
+
+func TestRexSetStatus(t *testing.T) {
+	// run in order as force changes the status of the ADR
+	tests := []struct {
+		name       string
+		configPath string
+		status     string
+		force      bool
+		err        bool
+	}{
+		{
+			name:       "not_allowed",
+			configPath: "tests/revision/docs/adr/",
+			status:     "Superseded",
+			err:        true,
+		},
+		{
+			name:       "force",
+			configPath: "tests/revision/docs/adr/",
+			status:     "Superseded",
+			force:      true,
+			err:        false,
+		},
+		{
+			name:       "error",
+			configPath: "/path/to/adr",
+			status:     "Proposed",
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", test.configPath)
+
+			r := New()
+			a, err := r.SetStatus(1, test.status, test.force)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+				assert.Equal(t, test.status, a.Content.Status, "")
+			}
+		})
+	}
+}
+
+func TestRexRelate(t *testing.T) {
+	relatePath := "tests/relate/docs/adr/"
+	err := createTestFolder(relatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"1-One.md", "2-Two.md"} {
+		err = os.WriteFile(
+			relatePath+name,
+			[]byte("# One\n\n| Status | Author |\n| ------ | ------ |\n| Accepted | Author |\n"),
+			0644,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		relation string
+		err      bool
+	}{
+		{name: "relates_to", relation: adr.RelatesTo, err: false},
+		{name: "unknown", relation: "blocks", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", relatePath)
+
+			r := New()
+			err := r.Relate(1, test.relation, 2)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+			}
+		})
+	}
+}
+
+func TestRexSupersede(t *testing.T) {
+	tests := []struct {
+		name       string
+		configPath string
+		err        bool
+	}{
+		{name: "good", configPath: "tests/relate/docs/adr/", err: false},
+		{name: "error", configPath: "/path/to/adr", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", test.configPath)
+
+			r := New()
+			a, err := r.Supersede(1, 2, false)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+				assert.Equal(t, "Superseded", a.Content.Status, "")
+			}
+		})
+	}
+}
+
+func TestRexRenumber(t *testing.T) {
+	tests := []struct {
+		name       string
+		configPath string
+		err        bool
+	}{
+		{name: "good", configPath: "tests/revision/docs/adr/", err: false},
+		{name: "error", configPath: "/path/to/adr", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", test.configPath)
+
+			r := New()
+			moves, err := r.Renumber(true)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+				assert.Empty(t, moves, "")
+			}
+		})
+	}
+}
+
+func TestRexReserve(t *testing.T) {
+	tests := []struct {
+		name         string
+		reservations string
+		id           int
+		err          bool
+	}{
+		{name: "not_set", reservations: "", err: true},
+		{name: "good", reservations: "tests/revision/reservations.yaml", id: 2, err: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("adr.path", "tests/revision/docs/adr/")
+			viper.Set("adr.reservations", test.reservations)
+			defer viper.Set("adr.reservations", "")
+
+			r := New()
+			res, err := r.Reserve("Reserved", "Author", "2025-01-05")
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+				assert.Equal(t, test.id, res.ID, "")
+			}
+		})
+	}
+}
+
+func TestRexFindADR(t *testing.T) {
+	tests := map[string]struct {
+		ref   string
+		title string
+		err   bool
+	}{
+		"id":      {ref: "1", title: "Revision"},
+		"slug":    {ref: "revision", title: "Revision"},
+		"missing": {ref: "missing", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.path", "tests/revision/docs/adr/")
+
+			r := New()
+			a, err := r.FindADR(test.ref)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+			} else {
+				assert.Nil(t, err, "")
+				assert.Equal(t, test.title, a.Content.Title, "")
+			}
+		})
+	}
+}
+
+func TestRexSearch(t *testing.T) {
+	tests := map[string]struct {
+		query string
+		ids   []int
+		err   bool
+	}{
+		"title":  {query: "revision", ids: []int{1}},
+		"filter": {query: "status:accepted", ids: nil},
+		"empty":  {query: "", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.path", "tests/revision/docs/adr/")
+
+			r := New()
+			results, err := r.Search(test.query)
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+				return
+			}
+
+			assert.Nil(t, err, "")
+			var ids []int
+			for _, res := range results {
+				ids = append(ids, res.ADR.ID)
+			}
+			assert.Equal(t, test.ids, ids, "")
+		})
+	}
+}
+
+func TestRexListADRs(t *testing.T) {
+	tests := map[string]struct {
+		configPath string
+		status     string
+		ids        []int
+		err        bool
+	}{
+		"good": {
+			configPath: "tests/revision/docs/adr/",
+			status:     "",
+			ids:        []int{1},
+			err:        false,
+		},
+		"filtered": {
+			configPath: "tests/revision/docs/adr/",
+			status:     "Accepted",
+			ids:        nil,
+			err:        false,
+		},
+		"error": {
+			configPath: "/path/to/adr",
+			err:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("adr.path", test.configPath)
+
+			r := New()
+			adrs, err := r.ListADRs(test.status, "")
+			if test.err {
+				assert.Error(t, err, fmt.Sprintf("Error: %v", err))
+				return
+			}
+			assert.Nil(t, err, "")
+
+			var ids []int
+			for _, a := range adrs {
+				ids = append(ids, a.ID)
+			}
+			assert.Equal(t, test.ids, ids, "")
+		})
+	}
+}
+
 func TestRexConfigGenereateIndex(t *testing.T) {
+	// index pages with and without a managed region
+	err := os.WriteFile(defaultAdrPath+"LEGACY.md", []byte("# Legacy\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(
+		defaultAdrPath+"MANAGED.md",
+		[]byte("# Managed\n\nProse\n\n<!-- rex:index:start -->\n<!-- rex:index:end -->\n"),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := map[string]struct {
 		configPath  string
 		configIndex string
@@ -301,13 +680,21 @@ func TestRexConfigGenereateIndex(t *testing.T) {
 			force:       true,
 			err:         false,
 		},
-		"error_force_false": {
+		"managed_force_false": {
 			configPath:  defaultAdrPath,
-			configIndex: "README.md",
+			configIndex: "MANAGED.md",
 			configAdd:   true,
 			expected:    []string{"1-test1.md", "2-test2.md"},
 			force:       false,
-			err:         true,
+			err:         false,
+		},
+		"legacy_force_false": {
+			configPath:  defaultAdrPath,
+			configIndex: "LEGACY.md",
+			configAdd:   true,
+			expected:    []string{"1-test1.md", "2-test2.md"},
+			force:       false,
+			err:         false,
 		},
 	}
 
@@ -321,11 +708,23 @@ func TestRexConfigGenereateIndex(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			if test.err {
 				assert.Error(t, err, fmt.Sprintf("Error: %v", err.Error()))
-			} else {
-				assert.Nil(t, err, "")
+				return
+			}
+			assert.Nil(t, err, "")
+
+			b, err := os.ReadFile(test.configPath + test.configIndex)
+			assert.Nil(t, err, "")
+			for _, e := range test.expected {
+				assert.Contains(t, string(b), "("+e+")", "")
 			}
 		})
 	}
+
+	// the managed region is added to an index page without one
+	b, err := os.ReadFile(defaultAdrPath + "LEGACY.md")
+	assert.Nil(t, err, "")
+	assert.True(t, strings.HasPrefix(string(b), "# Legacy\n\n<!-- rex:index:start -->\n"), string(b))
+	assert.True(t, strings.HasSuffix(string(b), "<!-- rex:index:end -->\n"), string(b))
 }
 
 func TestRexUpdateIndex(t *testing.T) {
@@ -351,6 +750,13 @@ func TestRexUpdateIndex(t *testing.T) {
 			force:       true,
 			err:         true,
 		},
+		"add_to_index_false": {
+			configPath:  "/path/to/adr",
+			configIndex: "README.md",
+			configAdd:   false,
+			force:       true,
+			err:         false,
+		},
 	}
 
 	for name, test := range tests {
@@ -403,3 +809,107 @@ func TestRexConfigGenereateDirectories(t *testing.T) {
 		})
 	}
 }
+
+func TestRexGeneratePages(t *testing.T) {
+	path := "tests/pages/"
+	defer os.RemoveAll(path)
+	viper.Set("adr.path", "tests/revision/docs/adr/")
+
+	viper.Set("enable_github_pages", false)
+	_, err := New().GeneratePages(path, false)
+	assert.EqualError(t, err, "GitHub Pages are disabled, set enable_github_pages: true in your .rex.yaml")
+
+	viper.Set("enable_github_pages", true)
+	defer viper.Set("enable_github_pages", false)
+
+	files, err := New().GeneratePages(path, false)
+	assert.Nil(t, err, "")
+	assert.Contains(t, files, path+"index.md", "")
+	assert.Contains(t, files, path+"adr/1-Revision.md", "")
+}
+
+func TestRexBuildSite(t *testing.T) {
+	path := "tests/site/"
+	defer os.RemoveAll(path)
+	viper.Set("adr.path", "tests/revision/docs/adr/")
+
+	files, err := New().BuildSite(path)
+	assert.Nil(t, err, "")
+	assert.Contains(t, files, path+"index.html", "")
+	assert.Contains(t, files, path+"style.css", "")
+	assert.Contains(t, files, path+"adr/1-Revision.html", "")
+
+	// a site isn't built without an ADR that can't be parsed
+	brokenPath := "tests/site-broken/docs/adr/"
+	err = createTestFolder(brokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(brokenPath+"1-Broken.md", []byte("---\nid: [\n---\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("adr.path", brokenPath)
+
+	_, err = New().BuildSite(path)
+	var skipped *adr.SkippedError
+	assert.ErrorAs(t, err, &skipped, "")
+}
+
+func TestRexServe(t *testing.T) {
+	viper.Set("adr.path", "tests/serve/missing/")
+	var out bytes.Buffer
+	err := New().Serve(context.Background(), "localhost:0", &out)
+	assert.Error(t, err, "the site is built before serving")
+
+	viper.Set("adr.path", "tests/revision/docs/adr/")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = New().Serve(ctx, "localhost:0", &out)
+	assert.Nil(t, err, "")
+	assert.Contains(t, out.String(), "serving ADRs at http://127.0.0.1:", "")
+}
+
+func TestRexFeedStatusChange(t *testing.T) {
+	adrPath := "tests/feed/docs/adr/"
+	sitePath := "tests/feed/public/"
+	err := createTestFolder(adrPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, title := range []string{"Use Go", "Use Postgres", "Use Redis"} {
+		err := os.WriteFile(
+			fmt.Sprintf("%s%d-%s.md", adrPath, i+1, strings.ReplaceAll(title, " ", "-")),
+			[]byte(fmt.Sprintf("---\nid: %d\ntitle: %s\nstatus: Draft\ndate: \"2025-01-0%d\"\n---\n# %s\n", i+1, title, i+1, title)),
+			0o644,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Set("adr.path", adrPath)
+	viper.Set("feed.url", "https://example.com/adrs/")
+	defer viper.Set("feed.url", "")
+
+	feed := func() string {
+		_, err := New().BuildSite(sitePath)
+		assert.Nil(t, err, "")
+		b, err := os.ReadFile(sitePath + "feed.xml")
+		assert.Nil(t, err, "")
+		return string(b)
+	}
+
+	f := feed()
+	assert.Less(t, strings.Index(f, "ADR 3: Use Redis"), strings.Index(f, "ADR 1: Use Go"), "")
+
+	// the oldest ADR moves to the top of the feed once its status changes
+	_, err = New().SetStatus(1, "Proposed", false)
+	assert.Nil(t, err, "")
+
+	f = feed()
+	assert.Less(t, strings.Index(f, "ADR 1: Use Go (Proposed)"), strings.Index(f, "ADR 3: Use Redis"), "")
+	assert.Contains(t, f, "<updated>"+time.Now().Format(time.DateOnly)+"T00:00:00Z</updated>", "")
+}
@@ -0,0 +1,345 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package serve serves a built site on localhost and reloads the browser
+// when the files it is built from change.
+package serve
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// ReloadPath is the path browsers listen on for reloads.
+	ReloadPath = "/_rex/reload"
+
+	// debounce is how long changes are collected for before the site is
+	// rebuilt, editors often write a file more than once when saving.
+	debounce = 100 * time.Millisecond
+)
+
+// reloadScript is added to every HTML page served, it reloads the page
+// when the site is rebuilt.
+var reloadScript = []byte(`<script>new EventSource("` + ReloadPath + `").onmessage = () => location.reload();</script>
+`)
+
+// Server serves the site built to Dir and rebuilds it when a file under
+// one of the Watch paths changes.
+type Server struct {
+	Dir   string       // directory the site is built to and served from
+	Watch []string     // files and directories watched for changes
+	Build func() error // builds the site to Dir
+	Log   io.Writer    // where builds and errors are logged
+
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+// New creates a Server for the site built to dir by build.
+func New(dir string, watch []string, build func() error) *Server {
+	return &Server{
+		Dir:     dir,
+		Watch:   watch,
+		Build:   build,
+		Log:     io.Discard,
+		clients: make(map[chan struct{}]struct{}),
+	}
+}
+
+// ListenAndServe builds the site, serves it on addr and rebuilds it on
+// changes until ctx is done.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	err := s.Build()
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := s.watcher()
+	if err != nil {
+		ln.Close()
+		return err
+	}
+	defer watcher.Close()
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go s.watch(ctx, watcher)
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	fmt.Fprintf(s.Log, "serving ADRs at http://%s\n", ln.Addr())
+
+	err = srv.Serve(ln)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Handler returns the handler serving the site with the reload script
+// added to HTML pages.
+func (s *Server) Handler() http.Handler {
+	files := http.FileServer(http.Dir(s.Dir))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(ReloadPath, s.events)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		name := path.Clean("/" + r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/") {
+			name = path.Join(name, "index.html")
+		}
+
+		if path.Ext(name) != ".html" {
+			files.ServeHTTP(w, r)
+			return
+		}
+
+		page, err := s.page(name)
+		if err != nil {
+			files.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write(page)
+	})
+
+	return mux
+}
+
+// page returns the HTML page name in the site with the reload script
+// added before the closing body tag, or at the end if there isn't one.
+func (s *Server) page(name string) ([]byte, error) {
+	f, err := http.Dir(s.Dir).Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fs.ErrNotExist
+	}
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	i := bytes.LastIndex(b, []byte("</body>"))
+	if i < 0 {
+		return append(b, reloadScript...), nil
+	}
+
+	page := make([]byte, 0, len(b)+len(reloadScript))
+	page = append(page, b[:i]...)
+	page = append(page, reloadScript...)
+	return append(page, b[i:]...), nil
+}
+
+// events streams a server sent event to the browser every time the site
+// is rebuilt.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	reload := s.subscribe()
+	defer s.unsubscribe(reload)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-reload:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) subscribe() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reload := make(chan struct{}, 1)
+	s.clients[reload] = struct{}{}
+	return reload
+}
+
+func (s *Server) unsubscribe(reload chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.clients, reload)
+}
+
+// Reload tells every connected browser to reload the page.
+func (s *Server) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for reload := range s.clients {
+		select {
+		case reload <- struct{}{}:
+		default:
+			// a reload is already waiting to be sent
+		}
+	}
+}
+
+// Rebuild builds the site and reloads the browsers. If the build fails
+// the error is logged and the last site built is still served.
+func (s *Server) Rebuild() {
+	err := s.Build()
+	if err != nil {
+		fmt.Fprintf(s.Log, "rebuilding the site failed: %s\n", err.Error())
+		return
+	}
+
+	fmt.Fprintf(s.Log, "rebuilt the site at %s\n", time.Now().Format(time.TimeOnly))
+	s.Reload()
+}
+
+// watcher returns a watcher on the Watch paths and every directory under
+// them. Watch paths that don't exist are skipped.
+func (s *Server) watcher() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range s.Watch {
+		if p == "" {
+			continue
+		}
+
+		_, err := os.Stat(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		err = addDirs(watcher, p)
+		if err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+
+	return watcher, nil
+}
+
+// addDirs watches path and every directory under it, fsnotify doesn't
+// watch directories recursively.
+func addDirs(watcher *fsnotify.Watcher, path string) error {
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || p == path {
+			return watcher.Add(p)
+		}
+		return nil
+	})
+}
+
+// watch rebuilds the site once changes under the watched paths have
+// settled until ctx is done.
+func (s *Server) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			// watch directories created after the server started
+			if event.Has(fsnotify.Create) {
+				info, err := os.Stat(event.Name)
+				if err == nil && info.IsDir() {
+					err = addDirs(watcher, event.Name)
+					if err != nil {
+						fmt.Fprintf(s.Log, "watching %s failed: %s\n", event.Name, err.Error())
+					}
+				}
+			}
+
+			timer.Reset(debounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(s.Log, "watching failed: %s\n", err.Error())
+
+		case <-timer.C:
+			s.Rebuild()
+		}
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package serve
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDir(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":        "<html><body><h1>ADRs</h1></body></html>",
+		"style.css":         "body {}",
+		"adr/1-use-go.html": "<p>Use Go</p>",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestHandler(t *testing.T) {
+	s := New(testDir(t), nil, func() error { return nil })
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	tests := map[string]struct {
+		path     string
+		status   int
+		expected string
+	}{
+		"index": {
+			path:     "/",
+			status:   http.StatusOK,
+			expected: "<html><body><h1>ADRs</h1>" + string(reloadScript) + "</body></html>",
+		},
+		"index page": {
+			path:     "/index.html",
+			status:   http.StatusOK,
+			expected: "<html><body><h1>ADRs</h1>" + string(reloadScript) + "</body></html>",
+		},
+		"no body": {
+			path:     "/adr/1-use-go.html",
+			status:   http.StatusOK,
+			expected: "<p>Use Go</p>" + string(reloadScript),
+		},
+		"css": {
+			path:     "/style.css",
+			status:   http.StatusOK,
+			expected: "body {}",
+		},
+		"missing": {
+			path:     "/adr/2-missing.html",
+			status:   http.StatusNotFound,
+			expected: "404 page not found\n",
+		},
+		"outside the site": {
+			path:     "/../serve_test.go",
+			status:   http.StatusNotFound,
+			expected: "404 page not found\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + test.path)
+			assert.Nil(t, err, "")
+			defer resp.Body.Close()
+
+			var b bytes.Buffer
+			_, err = b.ReadFrom(resp.Body)
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.status, resp.StatusCode, "")
+			assert.Equal(t, test.expected, b.String(), "")
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	s := New(testDir(t), nil, func() error { return nil })
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + ReloadPath)
+	assert.Nil(t, err, "")
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"), "")
+
+	// the browser is subscribed once the headers are sent
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.clients) == 1
+	}, time.Second, 10*time.Millisecond, "")
+
+	s.Rebuild()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	assert.Nil(t, err, "")
+	assert.Equal(t, "data: reload\n", line, "")
+}
+
+func TestRebuildError(t *testing.T) {
+	var log bytes.Buffer
+	s := New(testDir(t), nil, func() error { return errors.New("bad template") })
+	s.Log = &log
+
+	reload := s.subscribe()
+	defer s.unsubscribe(reload)
+
+	s.Rebuild()
+	assert.Equal(t, "rebuilding the site failed: bad template\n", log.String(), "")
+	assert.Len(t, reload, 0, "the browser isn't reloaded when a build fails")
+}
+
+func TestWatch(t *testing.T) {
+	adrPath := t.TempDir()
+	var builds atomic.Int32
+
+	s := New(testDir(t), []string{adrPath, filepath.Join(adrPath, "missing")}, func() error {
+		builds.Add(1)
+		return nil
+	})
+
+	watcher, err := s.watcher()
+	assert.Nil(t, err, "")
+	defer watcher.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.watch(ctx, watcher)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(adrPath, "1-use-go.md"), []byte("# Use Go\n"), 0o644))
+	assert.Eventually(t, func() bool { return builds.Load() == 1 }, 2*time.Second, 10*time.Millisecond, "")
+
+	// directories created while serving are watched
+	assert.NoError(t, os.Mkdir(filepath.Join(adrPath, "site"), 0o755))
+	assert.Eventually(t, func() bool { return builds.Load() == 2 }, 2*time.Second, 10*time.Millisecond, "")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(adrPath, "site", "adr.html"), []byte("adr"), 0o644))
+	assert.Eventually(t, func() bool { return builds.Load() == 3 }, 2*time.Second, 10*time.Millisecond, "")
+}
+
+func TestListenAndServe(t *testing.T) {
+	var log bytes.Buffer
+	s := New(testDir(t), nil, func() error { return errors.New("no adr path") })
+	s.Log = &log
+
+	err := s.ListenAndServe(context.Background(), "localhost:0")
+	assert.EqualError(t, err, "no adr path", "the site is built before serving")
+
+	s.Build = func() error { return nil }
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.ListenAndServe(ctx, "localhost:0") }()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.Nil(t, err, "")
+	case <-time.After(2 * time.Second):
+		t.Fatal("server didn't stop")
+	}
+	assert.True(t, strings.HasPrefix(log.String(), "serving ADRs at http://127.0.0.1:"), "")
+}
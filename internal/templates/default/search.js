@@ -0,0 +1,132 @@
+// Searches the ADRs of the site as you type in the search box. The index is
+// search.json at the root of the site, which rex writes with the site, and
+// is only fetched once something is searched for.
+//
+// The script tag sets the path to the root of the site with data-root.
+(() => {
+  const root = document.currentScript.dataset.root || "";
+  const input = document.getElementById("search-input");
+  const results = document.getElementById("search-results");
+  if (!input || !results) {
+    return;
+  }
+
+  let index;
+  const load = () => {
+    index ??= fetch(root + "search.json").then((resp) => {
+      if (!resp.ok) {
+        throw new Error(`fetching search.json: ${resp.status}`);
+      }
+      return resp.json();
+    });
+    return index;
+  };
+
+  const words = (text) =>
+    text.toLowerCase().split(/[^\p{L}\p{N}]+/u).filter((w) => w !== "");
+
+  const has = (text, term) => (text || "").toLowerCase().includes(term);
+
+  // score ranks an ADR against a term the same way rex adr search does,
+  // 0 if the term isn't found.
+  const score = (entry, term) => {
+    let s = 0;
+    if (String(entry.id) === term || has(entry.title, term)) {
+      s += 10;
+    }
+    if (entry.tags.some((tag) => has(tag, term))) {
+      s += 5;
+    }
+    if (has(entry.status, term) || has(entry.author, term)) {
+      s += 2;
+    }
+    if (entry.tokens.some((token) => token.startsWith(term))) {
+      s += 1;
+    }
+    return s;
+  };
+
+  const slug = (text) => text.toLowerCase().replace(/[^a-z0-9]+/g, "-").replace(/^-|-$/g, "");
+
+  const item = (entry) => {
+    const li = document.createElement("li");
+    const link = document.createElement("a");
+    link.href = root + entry.url;
+    link.textContent = `ADR ${entry.id}: ${entry.title}`;
+    li.append(link);
+
+    if (entry.status) {
+      const badge = document.createElement("span");
+      badge.className = `badge status-${slug(entry.status)}`;
+      badge.textContent = entry.status;
+      li.append(" ", badge);
+    }
+    if (entry.summary) {
+      const summary = document.createElement("p");
+      summary.textContent = entry.summary;
+      li.append(summary);
+    }
+    return li;
+  };
+
+  const search = async () => {
+    const query = input.value;
+    const terms = words(query);
+    if (terms.length === 0) {
+      results.replaceChildren();
+      results.hidden = true;
+      return;
+    }
+
+    let entries;
+    try {
+      entries = await load();
+    } catch (err) {
+      index = undefined;
+      results.replaceChildren(Object.assign(document.createElement("li"), {
+        textContent: "Search isn't available: " + err.message,
+      }));
+      results.hidden = false;
+      return;
+    }
+
+    // a newer search was started while the index was fetched
+    if (query !== input.value) {
+      return;
+    }
+
+    const matches = [];
+    for (const entry of entries) {
+      let total = 0;
+      for (const term of terms) {
+        const s = score(entry, term);
+        if (s === 0) {
+          total = 0;
+          break;
+        }
+        total += s;
+      }
+      if (total > 0) {
+        matches.push({ entry, total });
+      }
+    }
+    matches.sort((a, b) => b.total - a.total || a.entry.id - b.entry.id);
+
+    if (matches.length === 0) {
+      results.replaceChildren(Object.assign(document.createElement("li"), {
+        textContent: "No ADRs found",
+      }));
+    } else {
+      results.replaceChildren(...matches.map((m) => item(m.entry)));
+    }
+    results.hidden = false;
+  };
+
+  input.addEventListener("input", search);
+  input.addEventListener("keydown", (e) => {
+    if (e.key === "Escape") {
+      input.value = "";
+      search();
+    }
+  });
+})();
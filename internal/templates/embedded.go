@@ -24,9 +24,6 @@ package templates
 import (
 	"embed"
 	"fmt"
-	"log"
-	"os"
-	"strings"
 	"text/template"
 
 	"github.com/spf13/viper"
@@ -39,6 +36,10 @@ import (
 //go:embed default/adr.tmpl
 //go:embed default/index.tmpl
 //go:embed default/index_readme.tmpl
+//go:embed default/adr/*.tmpl
+//go:embed default/gh/*.tmpl default/gh/layouts/*.html
+//go:embed default/site/*
+//go:embed default/search.js
 var DefaultRexTemplates embed.FS
 
 // EmbeddedTemplate holds the Settings data
@@ -64,78 +65,69 @@ func (et *EmbeddedTemplate) Read(file string) ([]byte, error) {
 func (et *EmbeddedTemplate) Execute() {}
 
 // CreateADR creates adr files using the default embedded template
-func (et *EmbeddedTemplate) CreateADR(adr *adr.ADR) error {
-	// get the default template from settings and parse it
-	tmpl, err := template.ParseFS(
-		DefaultRexTemplates,
-		fmt.Sprintf("%s%s", et.Settings.TemplatePath, et.Settings.AdrTemplate),
-	)
+//
+// Returns the path of the file written.
+//
+// force: if an ADR file with the same name exists, this option will
+// overwrite it.
+func (et *EmbeddedTemplate) CreateADR(adr *adr.ADR, force bool) (string, error) {
+	// get the template named by the ADR, or the default template from
+	// settings, and parse it
+	tmpl, err := et.adrTemplate(adr.Content.Template)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// strip the ADR content to create a file name to use
-	strippedTitle := strings.Join(
-		strings.Split(strings.Trim(adr.Content.Title, "\n \t"), " "),
-		"-",
-	)
-	fileName := fmt.Sprintf("%d-%s.md", adr.ID, strippedTitle)
-
-	// create file on disk
-	var f *os.File
-	f, err = os.Create(viper.GetString("adr.path") + fileName)
+	// create a file name from the ADR id and title
+	fileName, err := adr.Config.FileName(adr.ID, adr.Content.Title)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
 
-	// execute template with ADR content
-	err = tmpl.Execute(f, adr)
-	if err != nil {
-		log.Fatal(err)
+	// check the ADR doesn't exist
+	file := viper.GetString("adr.path") + fileName
+	if !force && fileExists(file) {
+		return "", fmt.Errorf(
+			"ADR file found at %s, to overwrite please pass --force flag",
+			file,
+		)
 	}
 
-	err = f.Close()
+	// write file to disk with ADR content
+	err = writeTemplate(file, tmpl, adr)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+	return file, nil
+}
+
+// adrTemplate parses the ADR template called name, an empty name or
+// DefaultTemplateName is the default embedded template.
+func (et *EmbeddedTemplate) adrTemplate(name string) (*template.Template, error) {
+	if name != "" && name != DefaultTemplateName {
+		return namedTemplate(&et.Settings, name)
 	}
 
-	return nil
+	return parseEmbedded(
+		fmt.Sprintf("%s%s", et.Settings.TemplatePath, et.Settings.AdrTemplate),
+	)
 }
 
 // GenerateIndex creates the index of adrs using the embedded index template
 //
-// force: if an index already exists, this option will overwrite it.
+// If the index already exists and has a managed region, between
+// IndexStartMarker and IndexEndMarker, only the region is updated.
+//
+// force: if an index without a managed region already exists, this option
+// will overwrite it.
 func (et *EmbeddedTemplate) GenerateIndex(idx *adr.Index, force bool) error {
-	if force {
-		err := et.writeIndex(idx)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	// check index exists
-	if fileExists(idx.DocPath + idx.IndexFileName) {
-		return fmt.Errorf(
-			"index file found at %s, to overwrite please pass --force flag",
-			idx.DocPath+idx.IndexFileName,
-		)
-	}
-
-	err := et.writeIndex(idx)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return et.writeIndex(idx, force)
 }
 
 // writeIndex writes the index to disk using the default embedded template
-func (et *EmbeddedTemplate) writeIndex(idx *adr.Index) error {
+func (et *EmbeddedTemplate) writeIndex(idx *adr.Index, force bool) error {
 	// parse template from Settings
-	tmpl, err := template.ParseFS(
-		DefaultRexTemplates,
+	tmpl, err := parseEmbedded(
 		fmt.Sprintf(
 			"%s%s",
 			et.Settings.TemplatePath,
@@ -146,22 +138,20 @@ func (et *EmbeddedTemplate) writeIndex(idx *adr.Index) error {
 		return err
 	}
 
-	// create file on disk
-	var f *os.File
-	f, err = os.Create(idx.DocPath + idx.IndexFileName)
-	if err != nil {
-		log.Fatal(err)
-	}
+	// write file to disk with index template
+	return updateIndexFile(idx.DocPath+idx.IndexFileName, tmpl, idx, force)
+}
 
-	// execute index with index template
-	err = tmpl.Execute(f, idx)
-	if err != nil {
-		log.Fatal(err)
-	}
+// GeneratePages writes the GitHub Pages site using the embedded templates
+// and returns the files written.
+//
+// force: overwrite the Jekyll config and layouts if they already exist.
+func (et *EmbeddedTemplate) GeneratePages(site *Site, force bool) ([]string, error) {
+	return generatePages(site, "", force)
+}
 
-	err = f.Close()
-	if err != nil {
-		log.Fatal(err)
-	}
-	return nil
+// BuildSite writes the static HTML site using the embedded templates and
+// returns the files written.
+func (et *EmbeddedTemplate) BuildSite(site *StaticSite) ([]string, error) {
+	return buildStaticSite(site, "")
 }
@@ -67,12 +67,12 @@ func TestEmbeddedRead(t *testing.T) {
 	}{
 		"adr.tmpl": {
 			file:     "adr.tmpl",
-			contents: "# {{ .Content.Title }}\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| {{ .Content.Status }} | {{ .Content.Author }} | {{ .Content.Date }} | N/A | v0.0.1 |\n\n## Context and Problem Statement\n\n## Decision Drivers\n\n## Considered Options\n\n## Decision Outcome\n",
+			contents: "---\n{{ .FrontMatter }}---\n\n# {{ .Content.Title }}\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| {{ .Content.Status }} | {{ .Content.Author }} | {{ .Content.Date }} | {{ or .Content.Updated \"N/A\" }} | {{ .Content.Version }} |\n\n## Context and Problem Statement\n\n## Decision Drivers\n\n## Considered Options\n\n## Decision Outcome\n",
 			err:      false,
 		},
 		"index.tmpl": {
 			file:     "index.tmpl",
-			contents: "# {{ .Content.Title }}\n\n## ADRs\n\n| ID | Title | Link |\n| -- | ----- | ---- |\n{{- range .Content.Adrs }}\n| {{ .Id }} | {{ .Title }} | link |\n{{- end }}\n",
+			contents: "{{- define \"adrs\" }}\n| ID | Title | Status | Date | Author | Tags | Related |\n| -- | ----- | ------ | ---- | ------ | ---- | ------- |\n{{- range . }}\n| {{ .Id }} | [{{ .Title }}]({{ .Link }}) | {{ .Status }} | {{ .Date }} | {{ .Author }} | {{ range $i, $t := .Tags }}{{ if $i }}, {{ end }}{{ $t }}{{ end }} | {{ range $i, $r := .Relations }}{{ if $i }}, {{ end }}{{ if $r.File }}[{{ $r }}]({{ link $r.File }}){{ else }}{{ $r }}{{ end }}{{ end }} |\n{{- end }}\n{{- end -}}\n# {{ .Content.Title }}\n\n<!-- rex:index:start -->\n{{ if .Content.Groups }}\n{{- range .Content.Groups }}\n## {{ .Name }}\n{{ template \"adrs\" .Adrs }}\n{{ end }}\n{{- else }}\n## ADRs\n{{ template \"adrs\" .Content.Adrs }}\n{{ end }}\n<!-- rex:index:end -->\n",
 			err:      false,
 		},
 		"index_readme.tmpl": {
@@ -113,16 +113,17 @@ func TestEmbeddedCreateADR(t *testing.T) {
 		err     bool
 	}{
 		"adr": {
-			file: "3-Test-3.md",
+			file: "3-test-3.md",
 			content: parseContentWithDate(
-				"# Test 3\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | Author | %s | N/A | v0.0.1 |\n\n## Context and Problem Statement\n\n## Decision Drivers\n\n## Considered Options\n\n## Decision Outcome\n",
+				"---\nid: 3\ntitle: Test 3\nstatus: Draft\nauthors:\n  - Author\ndate: \"%[1]s\"\nversion: v0.0.1\n---\n\n# Test 3\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | Author | %[1]s | N/A | v0.0.1 |\n\n## Context and Problem Statement\n\n## Decision Drivers\n\n## Considered Options\n\n## Decision Outcome\n",
 			),
 			adr: &adr.ADR{
 				Content: adr.Content{
-					Title:  "Test 3",
-					Author: "Author",
-					Status: "Draft",
-					Date:   d,
+					Title:   "Test 3",
+					Author:  "Author",
+					Status:  "Draft",
+					Date:    d,
+					Version: "v0.0.1",
 				},
 				ID: 3,
 				Config: adr.ADRConfig{
@@ -140,7 +141,7 @@ func TestEmbeddedCreateADR(t *testing.T) {
 			viper.Set("templates.enabled", false)
 
 			tmp := NewTemplate()
-			err := tmp.CreateADR(test.adr)
+			_, err := tmp.CreateADR(test.adr, false)
 			if err != nil {
 				t.Errorf(
 					"error creating test file: %v, err: %v",
@@ -188,16 +189,66 @@ func TestEmbeddedGenereateIndex(t *testing.T) {
 	}{
 		"create": {
 			file:    defaultTemplatesAdrIndex,
-			content: "# ADR Index\n\n## ADRs\n\n| ID | Title | Link |\n| -- | ----- | ---- |\n| 1 | test1 | link |\n| 2 | test2 | link |\n| 3 | Test-3 | link |\n",
+			content: "# ADR Index\n\n<!-- rex:index:start -->\n\n## ADRs\n\n| ID | Title | Status | Date | Author | Tags | Related |\n| -- | ----- | ------ | ---- | ------ | ---- | ------- |\n| 1 | [test1](1-test1.md) | Superseded | 2025-01-05 | Author | db, storage | [Superseded by ADR 3](3-Test%203.md) |\n| 2 | [test2](2-test2.md) |  |  |  |  |  |\n| 3 | [Test-3](3-Test%203.md) | Accepted | 2025-02-01 | Jane Doe, John Doe |  | [Supersedes ADR 1](1-test1.md), Relates to ADR 2 |\n\n<!-- rex:index:end -->\n",
 			idx: &adr.Index{
 				DocPath:       defaultAdrPath,
 				IndexFileName: defaultTemplatesAdrIndex,
 				Content: adr.IndexContent{
 					Title: "ADR Index",
 					Adrs: []*adr.IndexAdr{
-						{Id: 1, Title: "test1"},
-						{Id: 2, Title: "test2"},
-						{Id: 3, Title: "Test-3"},
+						{
+							Id:     1,
+							Title:  "test1",
+							File:   "1-test1.md",
+							Status: "Superseded",
+							Date:   "2025-01-05",
+							Author: "Author",
+							Tags:   []string{"db", "storage"},
+							Relations: []adr.Relation{
+								{Type: adr.SupersededBy, ID: 3, File: "3-Test 3.md"},
+							},
+						},
+						{Id: 2, Title: "test2", File: "2-test2.md"},
+						{
+							Id:     3,
+							Title:  "Test-3",
+							File:   "3-Test 3.md",
+							Status: "Accepted",
+							Date:   "2025-02-01",
+							Author: "Jane Doe, John Doe",
+							Relations: []adr.Relation{
+								{Type: adr.Supersedes, ID: 1, File: "1-test1.md"},
+								{Type: adr.RelatesTo, ID: 2},
+							},
+						},
+					},
+				},
+			},
+			force: false,
+			err:   false,
+		},
+		"grouped": {
+			file:    "grouped_" + defaultTemplatesAdrIndex,
+			content: "# ADR Index\n\n<!-- rex:index:start -->\n\n## Accepted\n\n| ID | Title | Status | Date | Author | Tags | Related |\n| -- | ----- | ------ | ---- | ------ | ---- | ------- |\n| 1 | [test1](1-test1.md) | Accepted |  |  |  |  |\n| 3 | [test3](3-test3.md) | Accepted |  |  |  |  |\n\n## Draft\n\n| ID | Title | Status | Date | Author | Tags | Related |\n| -- | ----- | ------ | ---- | ------ | ---- | ------- |\n| 2 | [test2](2-test2.md) | Draft |  |  |  |  |\n\n<!-- rex:index:end -->\n",
+			idx: &adr.Index{
+				DocPath:       defaultAdrPath,
+				IndexFileName: "grouped_" + defaultTemplatesAdrIndex,
+				Content: adr.IndexContent{
+					Title: "ADR Index",
+					Groups: []*adr.IndexGroup{
+						{
+							Name: "Accepted",
+							Adrs: []*adr.IndexAdr{
+								{Id: 1, Title: "test1", File: "1-test1.md", Status: "Accepted"},
+								{Id: 3, Title: "test3", File: "3-test3.md", Status: "Accepted"},
+							},
+						},
+						{
+							Name: "Draft",
+							Adrs: []*adr.IndexAdr{
+								{Id: 2, Title: "test2", File: "2-test2.md", Status: "Draft"},
+							},
+						},
 					},
 				},
 			},
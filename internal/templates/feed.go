@@ -0,0 +1,402 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/fileutil"
+	"github.com/donaldgifford/rex/internal/markdown"
+)
+
+// DefaultFeedLimit is the most ADR's in a feed when "feed.limit" isn't
+// set.
+const DefaultFeedLimit = 20
+
+// Files of the feeds written to the root of a site.
+const (
+	atomFeed = "feed.xml"
+	rssFeed  = "rss.xml"
+)
+
+// Feed is an Atom feed, and optionally an RSS 2.0 feed, of decision
+// changes, ADR's that were created or changed status, written with the
+// GitHub Pages and static sites. It is configured
+// under "feed" in .rex.yaml.
+//
+// URL is where the site is published, links in feeds have to be absolute
+// so feeds are only written when it is set. Title defaults to the title of
+// the site.
+type Feed struct {
+	URL   string
+	Title string
+	RSS   bool
+	Limit int
+}
+
+// NewFeed reads the feed settings under "feed".
+func NewFeed() *Feed {
+	return &Feed{
+		URL:   viper.GetString("feed.url"),
+		Title: viper.GetString("feed.title"),
+		RSS:   viper.GetBool("feed.rss"),
+		Limit: cmp.Or(viper.GetInt("feed.limit"), DefaultFeedLimit),
+	}
+}
+
+// Enabled reports if feeds are written with the sites.
+func (f *Feed) Enabled() bool {
+	return f != nil && f.URL != ""
+}
+
+// FeedEntry is an ADR in a feed.
+//
+// The ID changes with the status of the ADR so feed readers show an ADR
+// again when it is accepted or superseded. Published is the date the ADR
+// was created and Updated its last update, set when its status changes, or
+// Published if it hasn't been updated.
+type FeedEntry struct {
+	ID        string
+	Title     string
+	URL       string
+	Author    string
+	Status    string
+	Tags      []string
+	Published time.Time
+	Updated   time.Time
+	Summary   string
+	Content   string
+}
+
+// feedEntries returns the entries of the ADR's with a valid date, the
+// latest updated first, up to the feed limit. adrs are ordered by id, base
+// is the URL of the root of the site and indexPage the ADR index page
+// links are rewritten from.
+func (f *Feed) feedEntries(base *url.URL, indexPage string, adrs []*adr.ADR) []*FeedEntry {
+	siteLinks := staticLinks(indexPage)
+
+	// newest first, the ADR's are ordered by id
+	entries := make([]*FeedEntry, 0, len(adrs))
+	for _, a := range slices.Backward(adrs) {
+		published, err := time.Parse(time.DateOnly, a.Content.Date)
+		if err != nil {
+			continue
+		}
+
+		updated := published
+		if u, err := time.Parse(time.DateOnly, a.Content.Updated); err == nil && u.After(published) {
+			updated = u
+		}
+
+		page := base.ResolveReference(&url.URL{Path: staticPageURL(a.File)})
+		renderer := &markdown.HTMLRenderer{Link: func(dest string) string {
+			u, err := url.Parse(siteLinks(dest))
+			if err != nil {
+				return dest
+			}
+			return page.ResolveReference(u).String()
+		}}
+
+		id := *page
+		id.Fragment = adr.Slug(a.Content.Status)
+
+		title := fmt.Sprintf("ADR %d: %s", a.ID, a.Content.Title)
+		if a.Content.Status != "" {
+			title += " (" + a.Content.Status + ")"
+		}
+
+		// the relations are listed before the content
+		prose := a.ProseWithoutRelations()
+		entries = append(entries, &FeedEntry{
+			ID:        id.String(),
+			Title:     title,
+			URL:       page.String(),
+			Author:    a.Content.Author,
+			Status:    a.Content.Status,
+			Tags:      a.Content.Tags,
+			Published: published,
+			Updated:   updated,
+			Summary:   markdown.Summary(prose, summaryLength),
+			Content:   feedRelations(base, a, adrs) + renderer.Render(prose),
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b *FeedEntry) int {
+		return b.Updated.Compare(a.Updated)
+	})
+
+	return entries[:min(len(entries), cmp.Or(f.Limit, DefaultFeedLimit))]
+}
+
+// feedRelations returns the relations of a as an HTML list linking to the
+// pages of the related ADR's on the site at base, or an empty string if it
+// has none.
+func feedRelations(base *url.URL, a *adr.ADR, adrs []*adr.ADR) string {
+	if len(a.Content.Relations) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("<ul>\n")
+	for _, r := range a.Content.Relations {
+		label := html.EscapeString(r.Label())
+		i := slices.IndexFunc(adrs, func(other *adr.ADR) bool { return other.ID == r.ID })
+		if i == -1 {
+			fmt.Fprintf(&b, "<li>%s ADR %d</li>\n", label, r.ID)
+			continue
+		}
+
+		other := adrs[i]
+		link := base.ResolveReference(&url.URL{Path: staticPageURL(other.File)})
+		fmt.Fprintf(&b, "<li>%s <a href=\"%s\">ADR %d: %s</a></li>\n",
+			label, html.EscapeString(link.String()), other.ID, html.EscapeString(other.Content.Title))
+	}
+	b.WriteString("</ul>\n")
+	return b.String()
+}
+
+// writeFeeds writes the feeds of adrs to the root of a site at sitePath
+// and returns the files written. Nothing is written if the feed isn't
+// enabled.
+func writeFeeds(f *Feed, sitePath, siteTitle, indexPage string, adrs []*adr.ADR) ([]string, error) {
+	if !f.Enabled() {
+		return nil, nil
+	}
+
+	base, err := url.Parse(f.URL)
+	if err != nil || !base.IsAbs() {
+		return nil, fmt.Errorf("invalid feed.url %q, it must be an absolute URL", f.URL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+
+	title := cmp.Or(f.Title, siteTitle)
+	entries := f.feedEntries(base, indexPage, adrs)
+
+	var written []string
+	write := func(name string, v any) error {
+		file := filepath.Join(sitePath, name)
+		err := fileutil.WriteFile(file, func(w io.Writer) error {
+			_, err := io.WriteString(w, xml.Header)
+			if err != nil {
+				return err
+			}
+
+			e := xml.NewEncoder(w)
+			e.Indent("", "  ")
+			err = e.Encode(v)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, "\n")
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		written = append(written, file)
+		return nil
+	}
+
+	err = write(atomFeed, newAtomFeed(base, title, entries))
+	if err != nil {
+		return nil, err
+	}
+
+	if f.RSS {
+		err = write(rssFeed, newRSSFeed(base, title, entries))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return written, nil
+}
+
+// feedUpdated returns when the feed was last updated, the update of its
+// latest entry or now if it has none.
+func feedUpdated(entries []*FeedEntry) time.Time {
+	if len(entries) == 0 {
+		return time.Now().UTC().Truncate(time.Second)
+	}
+	return entries[0].Updated
+}
+
+type atomFeedXML struct {
+	XMLName xml.Name        `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string          `xml:"title"`
+	ID      string          `xml:"id"`
+	Updated string          `xml:"updated"`
+	Links   []atomLinkXML   `xml:"link"`
+	Entries []*atomEntryXML `xml:"entry"`
+}
+
+type atomLinkXML struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntryXML struct {
+	Title      string            `xml:"title"`
+	ID         string            `xml:"id"`
+	Link       atomLinkXML       `xml:"link"`
+	Published  string            `xml:"published"`
+	Updated    string            `xml:"updated"`
+	Author     *atomPersonXML    `xml:"author,omitempty"`
+	Categories []atomCategoryXML `xml:"category"`
+	Summary    string            `xml:"summary,omitempty"`
+	Content    atomContentXML    `xml:"content"`
+}
+
+type atomPersonXML struct {
+	Name string `xml:"name"`
+}
+
+type atomCategoryXML struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContentXML struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// newAtomFeed returns the Atom feed of entries for the site at base.
+func newAtomFeed(base *url.URL, title string, entries []*FeedEntry) *atomFeedXML {
+	feed := &atomFeedXML{
+		Title:   title,
+		ID:      base.String(),
+		Updated: feedUpdated(entries).Format(time.RFC3339),
+		Links: []atomLinkXML{
+			{Href: base.String()},
+			{
+				Href: base.ResolveReference(&url.URL{Path: atomFeed}).String(),
+				Rel:  "self",
+				Type: "application/atom+xml",
+			},
+		},
+	}
+
+	for _, e := range entries {
+		entry := &atomEntryXML{
+			Title:     e.Title,
+			ID:        e.ID,
+			Link:      atomLinkXML{Href: e.URL},
+			Published: e.Published.Format(time.RFC3339),
+			Updated:   e.Updated.Format(time.RFC3339),
+			Summary:   e.Summary,
+			Content:   atomContentXML{Type: "html", Body: e.Content},
+		}
+		if e.Author != "" {
+			entry.Author = &atomPersonXML{Name: e.Author}
+		}
+		for _, c := range feedCategories(e) {
+			entry.Categories = append(entry.Categories, atomCategoryXML{Term: c})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+type rssFeedXML struct {
+	XMLName xml.Name      `xml:"rss"`
+	Version string        `xml:"version,attr"`
+	DC      string        `xml:"xmlns:dc,attr"`
+	Channel rssChannelXML `xml:"channel"`
+}
+
+type rssChannelXML struct {
+	Title         string        `xml:"title"`
+	Link          string        `xml:"link"`
+	Description   string        `xml:"description"`
+	LastBuildDate string        `xml:"lastBuildDate"`
+	Items         []*rssItemXML `xml:"item"`
+}
+
+type rssItemXML struct {
+	Title       string     `xml:"title"`
+	Link        string     `xml:"link"`
+	GUID        rssGUIDXML `xml:"guid"`
+	PubDate     string     `xml:"pubDate"`
+	Creator     string     `xml:"dc:creator,omitempty"`
+	Categories  []string   `xml:"category"`
+	Description string     `xml:"description"`
+}
+
+type rssGUIDXML struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	ID          string `xml:",chardata"`
+}
+
+// newRSSFeed returns the RSS 2.0 feed of entries for the site at base. An
+// item's date is when the ADR was last updated.
+func newRSSFeed(base *url.URL, title string, entries []*FeedEntry) *rssFeedXML {
+	feed := &rssFeedXML{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannelXML{
+			Title:         title,
+			Link:          base.String(),
+			Description:   title,
+			LastBuildDate: feedUpdated(entries).Format(time.RFC1123Z),
+		},
+	}
+
+	for _, e := range entries {
+		feed.Channel.Items = append(feed.Channel.Items, &rssItemXML{
+			Title:       e.Title,
+			Link:        e.URL,
+			GUID:        rssGUIDXML{ID: e.ID},
+			PubDate:     e.Updated.Format(time.RFC1123Z),
+			Creator:     e.Author,
+			Categories:  feedCategories(e),
+			Description: e.Summary,
+		})
+	}
+
+	return feed
+}
+
+// feedCategories returns the status and tags of an entry as feed
+// categories.
+func feedCategories(e *FeedEntry) []string {
+	var categories []string
+	if e.Status != "" {
+		categories = append(categories, e.Status)
+	}
+	return append(categories, e.Tags...)
+}
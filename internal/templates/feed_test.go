@@ -0,0 +1,189 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"encoding/xml"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/rex/internal/adr"
+)
+
+func TestNewFeed(t *testing.T) {
+	feed := NewFeed()
+	assert.Equal(t, &Feed{Limit: DefaultFeedLimit}, feed, "")
+	assert.False(t, feed.Enabled(), "")
+
+	viper.Set("feed.url", "https://example.com/adrs/")
+	viper.Set("feed.rss", true)
+	viper.Set("feed.limit", 5)
+	defer viper.Set("feed.url", "")
+	defer viper.Set("feed.rss", false)
+	defer viper.Set("feed.limit", 0)
+
+	feed = NewFeed()
+	assert.Equal(t, &Feed{URL: "https://example.com/adrs/", RSS: true, Limit: 5}, feed, "")
+	assert.True(t, feed.Enabled(), "")
+
+	var disabled *Feed
+	assert.False(t, disabled.Enabled(), "")
+}
+
+// testFeedADRs returns ADR's ordered by id for feeds.
+func testFeedADRs() []*adr.ADR {
+	return []*adr.ADR{
+		{
+			ID:   1,
+			File: "docs/adr/1-use-mysql.md",
+			Body: "# Use MySQL\n\nWe use MySQL.\n\n## Related ADRs\n\n- Superseded by [ADR 2: Use Postgres](2-use postgres.md)\n- Relates to ADR 9\n",
+			Content: adr.Content{
+				Title:     "Use MySQL",
+				Status:    "Superseded",
+				Author:    "Jane Doe",
+				Date:      "2025-01-05",
+				Updated:   "2025-03-01",
+				Relations: []adr.Relation{{Type: adr.SupersededBy, ID: 2}, {Type: adr.RelatesTo, ID: 9}},
+			},
+		},
+		{
+			ID:   2,
+			File: "docs/adr/2-use postgres.md",
+			Body: "# Use Postgres\n\nSee [MySQL](1-use-mysql.md) & the [index](README.md).\n",
+			Content: adr.Content{
+				Title:  "Use Postgres",
+				Status: "Accepted",
+				Date:   "2025-03-01",
+				Tags:   []string{"db"},
+			},
+		},
+		{
+			ID:      3,
+			File:    "docs/adr/3-cache.md",
+			Content: adr.Content{Title: "Cache", Status: "Draft", Date: "N/A"},
+		},
+		{
+			ID:      4,
+			File:    "docs/adr/4-queue.md",
+			Content: adr.Content{Title: "Queue", Status: "Proposed", Date: "2025-02-01"},
+		},
+	}
+}
+
+func TestFeedEntries(t *testing.T) {
+	base, err := url.Parse("https://example.com/adrs/")
+	assert.Nil(t, err, "")
+
+	entries := (&Feed{URL: base.String()}).feedEntries(base, "README.md", testFeedADRs())
+	assert.Equal(t, []*FeedEntry{
+		{
+			ID:        "https://example.com/adrs/adr/2-use%20postgres.html#accepted",
+			Title:     "ADR 2: Use Postgres (Accepted)",
+			URL:       "https://example.com/adrs/adr/2-use%20postgres.html",
+			Status:    "Accepted",
+			Tags:      []string{"db"},
+			Published: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			Updated:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			Summary:   "See MySQL & the index.",
+			Content:   "<p>See <a href=\"https://example.com/adrs/adr/1-use-mysql.html\">MySQL</a> &amp; the <a href=\"https://example.com/adrs/index.html\">index</a>.</p>\n",
+		},
+		{
+			ID:        "https://example.com/adrs/adr/1-use-mysql.html#superseded",
+			Title:     "ADR 1: Use MySQL (Superseded)",
+			URL:       "https://example.com/adrs/adr/1-use-mysql.html",
+			Author:    "Jane Doe",
+			Status:    "Superseded",
+			Published: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+			Updated:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			Summary:   "We use MySQL.",
+			Content:   "<ul>\n<li>Superseded by <a href=\"https://example.com/adrs/adr/2-use%20postgres.html\">ADR 2: Use Postgres</a></li>\n<li>Relates to ADR 9</li>\n</ul>\n<p>We use MySQL.</p>\n",
+		},
+		{
+			ID:        "https://example.com/adrs/adr/4-queue.html#proposed",
+			Title:     "ADR 4: Queue (Proposed)",
+			URL:       "https://example.com/adrs/adr/4-queue.html",
+			Status:    "Proposed",
+			Published: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			Updated:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, entries, "")
+
+	entries = (&Feed{URL: base.String(), Limit: 1}).feedEntries(base, "README.md", testFeedADRs())
+	assert.Len(t, entries, 1, "")
+	assert.Equal(t, "ADR 2: Use Postgres (Accepted)", entries[0].Title, "")
+}
+
+func TestWriteFeeds(t *testing.T) {
+	path := "tests/feed/"
+	defer os.RemoveAll(path)
+	assert.NoError(t, os.MkdirAll(path, 0o755))
+
+	files, err := writeFeeds(nil, path, "Decisions", "README.md", testFeedADRs())
+	assert.Nil(t, err, "")
+	assert.Nil(t, files, "no feeds are written without a feed url")
+
+	_, err = writeFeeds(&Feed{URL: "example.com"}, path, "Decisions", "README.md", testFeedADRs())
+	assert.EqualError(t, err, `invalid feed.url "example.com", it must be an absolute URL`)
+
+	files, err = writeFeeds(&Feed{URL: "https://example.com/adrs"}, path, "Decisions", "README.md", testFeedADRs())
+	assert.Nil(t, err, "")
+	assert.Equal(t, []string{path + "feed.xml"}, files, "")
+
+	b, err := os.ReadFile(path + "feed.xml")
+	assert.Nil(t, err, "")
+	var atom atomFeedXML
+	assert.Nil(t, xml.Unmarshal(b, &atom), "")
+	assert.Equal(t, "Decisions", atom.Title, "")
+	assert.Equal(t, "https://example.com/adrs/", atom.ID, "")
+	assert.Equal(t, "2025-03-01T00:00:00Z", atom.Updated, "")
+	assert.Equal(t, "https://example.com/adrs/feed.xml", atom.Links[1].Href, "")
+	assert.Len(t, atom.Entries, 3, "")
+	assert.Equal(t, "2025-01-05T00:00:00Z", atom.Entries[1].Published, "")
+	assert.Equal(t, &atomPersonXML{Name: "Jane Doe"}, atom.Entries[1].Author, "")
+	assert.Equal(t, []atomCategoryXML{{Term: "Accepted"}, {Term: "db"}}, atom.Entries[0].Categories, "")
+	assert.Equal(t, "html", atom.Entries[0].Content.Type, "")
+
+	files, err = writeFeeds(
+		&Feed{URL: "https://example.com/adrs/", Title: "ADR feed", RSS: true},
+		path, "Decisions", "README.md", testFeedADRs(),
+	)
+	assert.Nil(t, err, "")
+	assert.Equal(t, []string{path + "feed.xml", path + "rss.xml"}, files, "")
+
+	b, err = os.ReadFile(path + "rss.xml")
+	assert.Nil(t, err, "")
+	var rss rssFeedXML
+	assert.Nil(t, xml.Unmarshal(b, &rss), "")
+	assert.Equal(t, "2.0", rss.Version, "")
+	assert.Equal(t, "ADR feed", rss.Channel.Title, "")
+	assert.Equal(t, "Sat, 01 Mar 2025 00:00:00 +0000", rss.Channel.LastBuildDate, "")
+	assert.Len(t, rss.Channel.Items, 3, "")
+	assert.Equal(t, "https://example.com/adrs/adr/1-use-mysql.html#superseded", rss.Channel.Items[1].GUID.ID, "")
+	assert.Equal(t, "Sat, 01 Mar 2025 00:00:00 +0000", rss.Channel.Items[1].PubDate, "")
+	assert.Equal(t, "We use MySQL.", rss.Channel.Items[1].Description, "")
+	assert.Contains(t, string(b), "<dc:creator>Jane Doe</dc:creator>", "")
+}
@@ -0,0 +1,148 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/gitutil"
+)
+
+// FuncMap returns the functions available in every template.
+//
+//   - date LAYOUT VALUE: formats a time, or a "2006-01-02" or RFC 3339
+//     date string, with a Go time layout, IE: {{ date "Jan 2, 2006" .Content.Date }}
+//   - now: the current time, IE: {{ now | date "2006" }}
+//   - slug TEXT: the file name slug of text, IE: {{ slug .Content.Title }}
+//   - pad WIDTH NUMBER: pads number with zeros, IE: {{ pad 4 .ID }} = 0007
+//   - upper, lower, title TEXT: changes the case of text
+//   - join SEP LIST: joins a list, IE: {{ .Content.Tags | join ", " }}
+//   - default DEFAULT VALUE: value, or default if value is empty,
+//     IE: {{ .Content.Author | default "unknown" }}
+//   - rel FROM TO: the relative link from the directory FROM to the path TO
+//   - link PATH: path escaped for use in a markdown link
+//   - env NAME: the environment variable NAME
+//   - gitUser, gitBranch: the git user.name and the current git branch
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"date":      formatDate,
+		"now":       time.Now,
+		"slug":      adr.Slug,
+		"pad":       pad,
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"title":     cases.Title(language.Und).String,
+		"join":      join,
+		"default":   defaultValue,
+		"rel":       rel,
+		"link":      link,
+		"env":       os.Getenv,
+		"gitUser":   gitutil.UserName,
+		"gitBranch": gitutil.Branch,
+	}
+}
+
+// parseFiles parses the template files on disk with the FuncMap.
+func parseFiles(file string) (*template.Template, error) {
+	return template.New(filepath.Base(file)).Funcs(FuncMap()).ParseFiles(file)
+}
+
+// parseEmbedded parses the embedded template file with the FuncMap.
+func parseEmbedded(file string) (*template.Template, error) {
+	return template.New(path.Base(file)).Funcs(FuncMap()).ParseFS(DefaultRexTemplates, file)
+}
+
+// formatDate formats v with layout. v can be a time.Time or a date string
+// as "2006-01-02" or RFC 3339, other strings are returned unchanged.
+func formatDate(layout string, v any) string {
+	switch d := v.(type) {
+	case time.Time:
+		return d.Format(layout)
+	case string:
+		for _, l := range []string{time.DateOnly, time.RFC3339} {
+			if t, err := time.Parse(l, d); err == nil {
+				return t.Format(layout)
+			}
+		}
+		return d
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// pad formats n with leading zeros to width.
+func pad(width int, n any) string {
+	return fmt.Sprintf("%0*v", width, n)
+}
+
+// join joins the values in list with sep.
+func join(sep string, list any) string {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+
+	values := make([]string, 0, v.Len())
+	for i := range v.Len() {
+		values = append(values, fmt.Sprint(v.Index(i).Interface()))
+	}
+	return strings.Join(values, sep)
+}
+
+// defaultValue returns v, or def if v is the zero value of its type.
+func defaultValue(def, v any) any {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		return def
+	}
+	if rv := reflect.ValueOf(v); (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+		return def
+	}
+	return v
+}
+
+// rel returns the slash separated path to target relative to the directory
+// from. target is returned unchanged if there is no relative path.
+func rel(from, target string) string {
+	r, err := filepath.Rel(from, target)
+	if err != nil {
+		return target
+	}
+	return filepath.ToSlash(r)
+}
+
+// link escapes p for use as the target of a markdown link.
+func link(p string) string {
+	u := url.URL{Path: filepath.ToSlash(p)}
+	return u.EscapedPath()
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncMap(t *testing.T) {
+	t.Setenv("REX_TEST_ENV", "from env")
+
+	data := map[string]any{
+		"Title":  "Use Go for the CLI",
+		"Date":   "2024-03-05",
+		"Time":   time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC),
+		"ID":     7,
+		"Tags":   []string{"cli", "go"},
+		"Author": "",
+	}
+
+	tests := map[string]struct {
+		text     string
+		expected string
+	}{
+		"date_string":     {text: `{{ date "Jan 2, 2006" .Date }}`, expected: "Mar 5, 2024"},
+		"date_time":       {text: `{{ .Time | date "2006/01/02" }}`, expected: "2024/03/05"},
+		"date_invalid":    {text: `{{ date "2006" "someday" }}`, expected: "someday"},
+		"now":             {text: `{{ now | date "2006" }}`, expected: time.Now().Format("2006")},
+		"slug":            {text: `{{ slug .Title }}`, expected: "use-go-for-the-cli"},
+		"pad":             {text: `{{ pad 4 .ID }}`, expected: "0007"},
+		"upper":           {text: `{{ upper .Title }}`, expected: "USE GO FOR THE CLI"},
+		"lower":           {text: `{{ lower .Title }}`, expected: "use go for the cli"},
+		"title":           {text: `{{ title "use go for the cli" }}`, expected: "Use Go For The Cli"},
+		"join":            {text: `{{ .Tags | join ", " }}`, expected: "cli, go"},
+		"default_empty":   {text: `{{ .Author | default "unknown" }}`, expected: "unknown"},
+		"default_value":   {text: `{{ .Title | default "unknown" }}`, expected: "Use Go for the CLI"},
+		"default_nil":     {text: `{{ .Missing | default "none" }}`, expected: "none"},
+		"rel":             {text: `{{ rel "docs/adr" "docs/adr/1-first.md" }}`, expected: "1-first.md"},
+		"rel_parent":      {text: `{{ rel "site" "docs/adr/1-first.md" }}`, expected: "../docs/adr/1-first.md"},
+		"link":            {text: `{{ link "docs/adr/1 first.md" }}`, expected: "docs/adr/1%20first.md"},
+		"env":             {text: `{{ env "REX_TEST_ENV" }}`, expected: "from env"},
+		"env_missing":     {text: `{{ env "REX_TEST_ENV_MISSING" | default "unset" }}`, expected: "unset"},
+		"git_user_type":   {text: `{{ printf "%T" gitUser }}`, expected: "string"},
+		"git_branch_type": {text: `{{ printf "%T" gitBranch }}`, expected: "string"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := template.New(name).Funcs(FuncMap()).Parse(test.text)
+			assert.NoError(t, err)
+
+			var b bytes.Buffer
+			assert.NoError(t, tmpl.Execute(&b, data))
+			assert.Equal(t, test.expected, b.String(), "")
+		})
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	file := "tests/funcs/adr.tmpl"
+	assert.NoError(t, os.MkdirAll("tests/funcs", 0o755))
+	defer os.RemoveAll("tests/funcs")
+
+	assert.NoError(t, os.WriteFile(file, []byte(`# {{ pad 3 .ID }} {{ upper .Title }}`), 0o644))
+
+	tmpl, err := parseFiles(file)
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	assert.NoError(t, tmpl.Execute(&b, map[string]any{"ID": 2, "Title": "funcs"}))
+	assert.Equal(t, "# 002 FUNCS", b.String(), "")
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/donaldgifford/rex/internal/fileutil"
+)
+
+// Markers around the managed region of an index page. When an index page
+// has the markers only the region between them is rewritten, anything
+// written outside of it is kept.
+const (
+	IndexStartMarker = "<!-- rex:index:start -->"
+	IndexEndMarker   = "<!-- rex:index:end -->"
+)
+
+// updateIndexFile writes tmpl executed with data to the index page at path.
+//
+// If the index page exists and has a managed region, the region is replaced
+// with the one in the output, or the whole output if it has no region. An
+// existing index page without a managed region has one added to its end,
+// unless force is set which overwrites the whole page.
+func updateIndexFile(path string, tmpl *template.Template, data any, force bool) error {
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, data)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	output := b.String()
+
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		content, ok, err := replaceIndexRegion(string(existing), output)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		switch {
+		case ok:
+			output = content
+		case !force:
+			output, err = appendIndexRegion(string(existing), output)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+
+	return fileutil.WriteFile(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, output)
+		return err
+	})
+}
+
+// replaceIndexRegion replaces the managed region in content with the
+// region in output. Returns false if content has no managed region.
+func replaceIndexRegion(content, output string) (string, bool, error) {
+	start, end, err := indexRegion(content)
+	if err != nil || start == -1 {
+		return "", false, err
+	}
+
+	region, err := outputRegion(output)
+	if err != nil {
+		return "", false, err
+	}
+
+	return content[:start] + region + content[end:], true, nil
+}
+
+// appendIndexRegion adds the managed region in output to the end of
+// content, keeping what was written by hand.
+func appendIndexRegion(content, output string) (string, error) {
+	region, err := outputRegion(output)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(content, "\n") + "\n\n" + region + "\n", nil
+}
+
+// outputRegion returns the managed region of output, including the
+// markers, or the whole output between markers if it has no region.
+func outputRegion(output string) (string, error) {
+	start, end, err := indexRegion(output)
+	if err != nil {
+		return "", err
+	}
+	if start == -1 {
+		return IndexStartMarker + "\n" + strings.TrimSpace(output) + "\n" + IndexEndMarker, nil
+	}
+	return output[start:end], nil
+}
+
+// indexRegion returns the start and end of the managed region, including
+// the markers. Returns -1 if there isn't one.
+func indexRegion(content string) (int, int, error) {
+	start := strings.Index(content, IndexStartMarker)
+	end := strings.Index(content, IndexEndMarker)
+
+	switch {
+	case start == -1 && end == -1:
+		return -1, -1, nil
+	case start == -1 || end < start:
+		return -1, -1, fmt.Errorf(
+			"index managed region must start with %s and end with %s",
+			IndexStartMarker,
+			IndexEndMarker,
+		)
+	}
+
+	return start, end + len(IndexEndMarker), nil
+}
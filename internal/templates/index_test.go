@@ -0,0 +1,145 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"os"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceIndexRegion(t *testing.T) {
+	tests := map[string]struct {
+		content  string
+		output   string
+		expected string
+		ok       bool
+		err      bool
+	}{
+		"no_region": {
+			content: "# ADRs\n",
+			output:  "# ADRs\n\n<!-- rex:index:start -->\nnew\n<!-- rex:index:end -->\n",
+			ok:      false,
+		},
+		"region": {
+			content:  "# My ADRs\n\nProse\n\n<!-- rex:index:start -->\nold\n<!-- rex:index:end -->\n\nMore prose\n",
+			output:   "# ADRs\n\n<!-- rex:index:start -->\nnew\n<!-- rex:index:end -->\n",
+			expected: "# My ADRs\n\nProse\n\n<!-- rex:index:start -->\nnew\n<!-- rex:index:end -->\n\nMore prose\n",
+			ok:       true,
+		},
+		"output_without_region": {
+			content:  "Prose\n<!-- rex:index:start -->\nold\n<!-- rex:index:end -->\n",
+			output:   "\n| 1 | new |\n",
+			expected: "Prose\n<!-- rex:index:start -->\n| 1 | new |\n<!-- rex:index:end -->\n",
+			ok:       true,
+		},
+		"missing_end": {
+			content: "<!-- rex:index:start -->\nold\n",
+			output:  "new",
+			err:     true,
+		},
+		"reversed": {
+			content: "<!-- rex:index:end -->\nold\n<!-- rex:index:start -->\n",
+			output:  "new",
+			err:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, ok, err := replaceIndexRegion(test.content, test.output)
+			if test.err {
+				assert.Error(t, err, "")
+				return
+			}
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.ok, ok, "")
+			assert.Equal(t, test.expected, actual, "")
+		})
+	}
+}
+
+func TestUpdateIndexFile(t *testing.T) {
+	indexPath := "tests/update_index/"
+	err := createTestFolder(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := template.Must(template.New("index").Parse(
+		"# {{ . }}\n\n<!-- rex:index:start -->\n{{ . }}\n<!-- rex:index:end -->\n",
+	))
+
+	tests := []struct {
+		name     string
+		existing string
+		force    bool
+		expected string
+		err      bool
+	}{
+		{
+			name:     "new",
+			expected: "# ADRs\n\n<!-- rex:index:start -->\nADRs\n<!-- rex:index:end -->\n",
+		},
+		{
+			name:     "managed",
+			existing: "# Decisions\n\nKeep me\n\n<!-- rex:index:start -->\nold\n<!-- rex:index:end -->\n",
+			expected: "# Decisions\n\nKeep me\n\n<!-- rex:index:start -->\nADRs\n<!-- rex:index:end -->\n",
+		},
+		{
+			name:     "unmanaged",
+			existing: "# Decisions\n\nSome hand prose.\n\n",
+			expected: "# Decisions\n\nSome hand prose.\n\n<!-- rex:index:start -->\nADRs\n<!-- rex:index:end -->\n",
+		},
+		{
+			name:     "unmanaged_force",
+			existing: "# Decisions\n",
+			force:    true,
+			expected: "# ADRs\n\n<!-- rex:index:start -->\nADRs\n<!-- rex:index:end -->\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := indexPath + test.name + ".md"
+			if test.existing != "" {
+				err := os.WriteFile(file, []byte(test.existing), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := updateIndexFile(file, tmpl, "ADRs", test.force)
+			if test.err {
+				assert.Error(t, err, "")
+			} else {
+				assert.Nil(t, err, "")
+			}
+
+			b, err := os.ReadFile(file)
+			assert.Nil(t, err, "")
+			assert.Equal(t, test.expected, string(b), "")
+		})
+	}
+}
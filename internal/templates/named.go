@@ -0,0 +1,98 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultTemplateName selects the default ADR template.
+const DefaultTemplateName = "default"
+
+// builtinTemplates are the embedded ADR templates that can be selected by
+// name, IE: "rex adr create --template madr".
+var builtinTemplates = map[string]string{
+	"madr":            "default/adr/madr.tmpl",
+	"nygard":          "default/adr/nygard.tmpl",
+	"y-statement":     "default/adr/y-statement.tmpl",
+	"lightweight":     "default/adr/lightweight.tmpl",
+	"security-review": "default/adr/security-review.tmpl",
+}
+
+// Names returns the ADR templates that can be selected, the built in
+// templates and the named templates in settings, ordered by name.
+func Names(settings *Settings) []string {
+	names := slices.Collect(maps.Keys(builtinTemplates))
+	for name := range settings.Named {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	return append([]string{DefaultTemplateName}, names...)
+}
+
+// namedTemplate parses the ADR template called name. Templates named in
+// settings are read from disk and take precedence over the built in
+// templates.
+func namedTemplate(settings *Settings, name string) (*template.Template, error) {
+	if file, ok := settings.Named[name]; ok {
+		return parseFiles(file)
+	}
+
+	if file, ok := builtinTemplates[name]; ok {
+		return parseEmbedded(file)
+	}
+
+	return nil, CheckName(settings, name)
+}
+
+// CheckName returns an error if there is no ADR template called name. An
+// empty name selects the default template.
+func CheckName(settings *Settings, name string) error {
+	if name == "" || slices.Contains(Names(settings), name) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"unknown ADR template %q, must be one of: %s",
+		name,
+		strings.Join(Names(settings), ", "),
+	)
+}
+
+// namedTemplates reads the templates named under "templates.adr.named",
+// returning the path of each template on disk by name.
+func namedTemplates() map[string]string {
+	named := map[string]string{}
+	for name, file := range viper.GetStringMapString("templates.adr.named") {
+		named[name] = viper.GetString("templates.path") + file
+	}
+	return named
+}
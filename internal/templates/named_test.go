@@ -0,0 +1,190 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/markdown"
+)
+
+func TestNames(t *testing.T) {
+	tests := map[string]struct {
+		named    map[string]string
+		expected []string
+	}{
+		"builtin": {
+			expected: []string{"default", "lightweight", "madr", "nygard", "security-review", "y-statement"},
+		},
+		"named": {
+			named:    map[string]string{"team": "team.tmpl", "madr": "madr.tmpl"},
+			expected: []string{"default", "lightweight", "madr", "nygard", "security-review", "team", "y-statement"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Names(&Settings{Named: test.named}), "")
+		})
+	}
+}
+
+func TestCheckName(t *testing.T) {
+	settings := &Settings{Named: map[string]string{"team": "team.tmpl"}}
+
+	assert.Nil(t, CheckName(settings, ""), "")
+	assert.Nil(t, CheckName(settings, "default"), "")
+	assert.Nil(t, CheckName(settings, "nygard"), "")
+	assert.Nil(t, CheckName(settings, "team"), "")
+	assert.EqualError(
+		t,
+		CheckName(settings, "missing"),
+		"unknown ADR template \"missing\", must be one of: default, lightweight, madr, nygard, security-review, team, y-statement",
+	)
+}
+
+func TestCreateADRNamed(t *testing.T) {
+	namedPath := "tests/named/"
+	err := createTestFolder(namedPath + "adr/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(namedPath+"team.tmpl", []byte("---\n{{ .FrontMatter }}---\n\n# {{ .Content.Title }}\n\n## Team\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("adr.path", namedPath+"adr/")
+	viper.Set("templates.path", namedPath)
+	viper.Set("templates.adr.named", map[string]string{"team": "team.tmpl"})
+	defer func() {
+		viper.Set("adr.path", defaultAdrPath)
+		viper.Set("templates.path", defaultTemplatesPath)
+		viper.Set("templates.adr.named", nil)
+	}()
+
+	tests := map[string]struct {
+		enabled bool
+		section string
+	}{
+		"madr":            {section: "## Pros and Cons of the Options"},
+		"nygard":          {section: "## Consequences"},
+		"y-statement":     {section: "In the context of"},
+		"lightweight":     {section: "## Decision"},
+		"security-review": {section: "## Residual Risk"},
+		"team":            {section: "## Team"},
+		"default":         {section: "## Decision Drivers"},
+	}
+
+	id := 0
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id++
+			a := &adr.ADR{
+				ID:     id,
+				Config: *adr.NewADRConfig(),
+				Content: adr.Content{
+					Title:    "Named " + name,
+					Author:   "Author",
+					Status:   "Draft",
+					Version:  "v0.0.1",
+					Template: name,
+				},
+			}
+
+			file, err := NewTemplate().CreateADR(a, false)
+			assert.Nil(t, err, "")
+
+			b, err := os.ReadFile(file)
+			assert.Nil(t, err, "")
+			assert.Contains(t, string(b), test.section, "")
+
+			// every template writes front matter rex can read back
+			parsed, err := adr.Parse(file)
+			assert.Nil(t, err, "")
+			assert.Equal(t, id, parsed.ID, "")
+			assert.Equal(t, "Named "+name, parsed.Content.Title, "")
+			assert.Equal(t, "Draft", parsed.Content.Status, "")
+		})
+	}
+
+	_, err = NewTemplate().CreateADR(&adr.ADR{
+		ID:      99,
+		Config:  *adr.NewADRConfig(),
+		Content: adr.Content{Title: "Missing", Template: "missing"},
+	}, false)
+	assert.Error(t, err, "")
+}
+
+func TestRenderNamedTemplate(t *testing.T) {
+	renderPath := "tests/render/"
+	err := createTestFolder(renderPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("adr.path", renderPath)
+	defer viper.Set("adr.path", defaultAdrPath)
+
+	tests := map[string]struct {
+		expected string
+	}{
+		"madr":            {expected: "<h3 id=\"consequences\">Consequences</h3>\n<ul>\n<li>Good, because </li>\n"},
+		"nygard":          {expected: "<h2 id=\"context\">Context</h2>\n<h2 id=\"decision\">Decision</h2>\n"},
+		"y-statement":     {expected: "<p>In the context of , facing , we decided for and neglected , to achieve , accepting , because .</p>\n"},
+		"security-review": {expected: "<h2 id=\"assets-and-data\">Assets and Data</h2>\n<h2 id=\"threats\">Threats</h2>\n"},
+	}
+
+	id := 0
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id++
+			file, err := NewTemplate().CreateADR(&adr.ADR{
+				ID:     id,
+				Config: *adr.NewADRConfig(),
+				Content: adr.Content{
+					Title:    "Render " + name,
+					Author:   "Author",
+					Status:   "Draft",
+					Version:  "v0.0.1",
+					Template: name,
+				},
+			}, false)
+			assert.Nil(t, err, "")
+
+			a, err := adr.Parse(file)
+			assert.Nil(t, err, "")
+
+			// the placeholder comments of the template aren't rendered
+			html := (&markdown.HTMLRenderer{}).Render(a.Prose())
+			assert.NotContains(t, html, "<!--", "")
+			assert.NotContains(t, html, "-->", "")
+			assert.Contains(t, html, test.expected, "")
+		})
+	}
+}
@@ -0,0 +1,366 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/donaldgifford/rex/internal/adr"
+)
+
+// Defaults for the GitHub Pages site when they aren't set under "pages".
+const (
+	DefaultPagesPath  = "pages/"
+	DefaultPagesTitle = "Architecture Decision Records"
+)
+
+const (
+	// pagesTemplatePath is the embedded directory of the GitHub Pages
+	// templates, a "gh" directory in the templates path overrides them.
+	pagesTemplatePath = "default/gh"
+
+	// pagesLayouts is the directory the Jekyll layout templates are in.
+	// Layouts are Liquid templates, so they are parsed with "[[" and "]]"
+	// as delimiters to leave "{{" and "}}" to Jekyll.
+	pagesLayouts = "layouts"
+
+	// siteADRDir is the directory of the site the ADR pages are written to.
+	siteADRDir = "adr"
+
+	// siteLayoutsDir is the directory of the site Jekyll reads layouts from.
+	siteLayoutsDir = "_layouts"
+)
+
+// Site is a Jekyll site for GitHub Pages with a page for every ADR and an
+// index page listing them. It is configured under "pages" in .rex.yaml.
+//
+// Path is the directory the site is written to. Index and Config are the
+// file names of the index page and Jekyll config, ADRLayout and
+// DefaultLayout the file names of the layouts. Feed is written with the
+// site when it is enabled. ADRs and ADRIndex hold the ADR's the pages are
+// generated from.
+type Site struct {
+	Path          string
+	Title         string
+	Index         string
+	Config        string
+	ADRLayout     string
+	DefaultLayout string
+	Feed          *Feed
+	ADRs          []*adr.ADR
+	ADRIndex      *adr.Index
+}
+
+// NewSite reads the site settings under "pages", using the defaults for
+// any that aren't set.
+func NewSite() *Site {
+	return &Site{
+		Path:          cmp.Or(viper.GetString("pages.path"), DefaultPagesPath),
+		Title:         cmp.Or(viper.GetString("pages.title"), DefaultPagesTitle),
+		Index:         cmp.Or(viper.GetString("pages.index"), "index.md"),
+		Config:        cmp.Or(viper.GetString("pages.web.config"), "_config.yml"),
+		ADRLayout:     cmp.Or(viper.GetString("pages.web.layout.adr"), "adr.html"),
+		DefaultLayout: cmp.Or(viper.GetString("pages.web.layout.default"), "default.html"),
+		Feed:          NewFeed(),
+	}
+}
+
+// ADRDir returns the directory of the site the ADR pages are written to.
+func (s *Site) ADRDir() string {
+	return siteADRDir
+}
+
+// LayoutName returns the name Jekyll knows the layout file by, the file
+// name without its extension.
+func (s *Site) LayoutName(file string) string {
+	return strings.TrimSuffix(file, filepath.Ext(file))
+}
+
+// SiteADR is the data the ADR page template is executed with.
+//
+// WebTitle is the title of the page, IE: "ADR 3: Use Postgres".
+type SiteADR struct {
+	Site     *Site
+	ADR      *adr.ADR
+	WebTitle string
+}
+
+// FrontMatter returns the Jekyll front matter of the ADR page without the
+// delimiters.
+func (sa *SiteADR) FrontMatter() (string, error) {
+	return pageFrontMatter(struct {
+		Layout string   `yaml:"layout"`
+		Title  string   `yaml:"title"`
+		ADR    int      `yaml:"adr"`
+		Status string   `yaml:"status,omitempty"`
+		Author string   `yaml:"author,omitempty"`
+		Date   string   `yaml:"date,omitempty"`
+		Tags   []string `yaml:"tags,omitempty"`
+	}{
+		Layout: sa.Site.LayoutName(sa.Site.ADRLayout),
+		Title:  sa.WebTitle,
+		ADR:    sa.ADR.ID,
+		Status: sa.ADR.Content.Status,
+		Author: sa.ADR.Content.Author,
+		Date:   sa.ADR.Content.Date,
+		Tags:   sa.ADR.Content.Tags,
+	})
+}
+
+// SiteIndex is the data the site index page template is executed with.
+type SiteIndex struct {
+	Site  *Site
+	Title string
+	Index *adr.Index
+}
+
+// FrontMatter returns the Jekyll front matter of the index page without
+// the delimiters.
+func (si *SiteIndex) FrontMatter() (string, error) {
+	return pageFrontMatter(struct {
+		Layout string `yaml:"layout"`
+		Title  string `yaml:"title"`
+	}{
+		Layout: si.Site.LayoutName(si.Site.DefaultLayout),
+		Title:  si.Title,
+	})
+}
+
+// Groups returns the ADR's in the index grouped as set in the ADR index,
+// an ungrouped index has one group without a Name.
+func (si *SiteIndex) Groups() []*SiteGroup {
+	if len(si.Index.Content.Groups) == 0 {
+		return []*SiteGroup{{ADRs: siteLinks(si.Index.Content.Adrs)}}
+	}
+
+	groups := make([]*SiteGroup, 0, len(si.Index.Content.Groups))
+	for _, g := range si.Index.Content.Groups {
+		groups = append(groups, &SiteGroup{Name: g.Name, ADRs: siteLinks(g.Adrs)})
+	}
+	return groups
+}
+
+// SiteGroup is a section of the site index page.
+type SiteGroup struct {
+	Name string
+	ADRs []*SiteLink
+}
+
+// SiteLink is an ADR in the site index page with the URL of its page,
+// relative to the index page.
+type SiteLink struct {
+	*adr.IndexAdr
+	URL string
+}
+
+// siteLinks returns the links to the pages of adrs.
+func siteLinks(adrs []*adr.IndexAdr) []*SiteLink {
+	links := make([]*SiteLink, 0, len(adrs))
+	for _, a := range adrs {
+		page := strings.TrimSuffix(a.File, filepath.Ext(a.File)) + ".html"
+		links = append(links, &SiteLink{
+			IndexAdr: a,
+			URL:      link(path.Join(siteADRDir, page)),
+		})
+	}
+	return links
+}
+
+// pageFrontMatter renders v as YAML front matter without the delimiters.
+func pageFrontMatter(v any) (string, error) {
+	var b bytes.Buffer
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+
+	err := e.Encode(v)
+	if err != nil {
+		return "", err
+	}
+
+	err = e.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// pageTemplate parses the GitHub Pages template name from dir, falling
+// back to the embedded template if dir is empty or doesn't have it.
+func pageTemplate(dir, name string) (*template.Template, error) {
+	tmpl := template.New(path.Base(name)).Funcs(FuncMap())
+	if path.Dir(name) == pagesLayouts {
+		tmpl = tmpl.Delims("[[", "]]")
+	}
+
+	if dir != "" && fileExists(filepath.Join(dir, name)) {
+		return tmpl.ParseFiles(filepath.Join(dir, name))
+	}
+	return tmpl.ParseFS(DefaultRexTemplates, path.Join(pagesTemplatePath, name))
+}
+
+// removeStalePages removes the files in dir with the extension ext that
+// aren't in pages, the pages of ADR's that were removed or renamed.
+func removeStalePages(dir, ext string, pages map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ext && !pages[e.Name()] {
+			err := os.Remove(filepath.Join(dir, e.Name()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// generatePages writes the site with the templates in dir, see
+// pageTemplate, and returns the files written.
+//
+// The Jekyll config and layouts are only written if they don't exist, so
+// they can be changed by hand, unless force is set. The ADR pages are
+// always written, pages left in the ADR directory by removed or renamed
+// ADR's are removed, and the index page is updated the same as the ADR
+// index, see updateIndexFile.
+func generatePages(site *Site, dir string, force bool) ([]string, error) {
+	adrDir := filepath.Join(site.Path, siteADRDir)
+	if site.ADRIndex != nil &&
+		filepath.Clean(adrDir) == filepath.Clean(site.ADRIndex.DocPath) {
+		return nil, fmt.Errorf(
+			"pages can't be written to %s, it is the ADR path, set pages.path to another directory",
+			site.Path,
+		)
+	}
+
+	for _, d := range []string{adrDir, filepath.Join(site.Path, siteLayoutsDir)} {
+		err := os.MkdirAll(d, 0o755)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var written []string
+	write := func(file, name string, data any) error {
+		tmpl, err := pageTemplate(dir, name)
+		if err != nil {
+			return err
+		}
+
+		err = writeTemplate(file, tmpl, data)
+		if err != nil {
+			return err
+		}
+		written = append(written, file)
+		return nil
+	}
+
+	// files that are only created once
+	for _, p := range [][2]string{
+		{filepath.Join(site.Path, site.Config), "config.tmpl"},
+		{filepath.Join(site.Path, siteLayoutsDir, site.DefaultLayout), path.Join(pagesLayouts, "default.html")},
+		{filepath.Join(site.Path, siteLayoutsDir, site.ADRLayout), path.Join(pagesLayouts, "adr.html")},
+	} {
+		if !force && fileExists(p[0]) {
+			continue
+		}
+
+		err := write(p[0], p[1], site)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pages := map[string]bool{}
+	for _, a := range site.ADRs {
+		page := filepath.Base(a.File)
+		err := write(
+			filepath.Join(adrDir, page),
+			"adr.tmpl",
+			&SiteADR{
+				Site:     site,
+				ADR:      a,
+				WebTitle: fmt.Sprintf("ADR %d: %s", a.ID, a.Content.Title),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		pages[page] = true
+	}
+
+	// remove the pages of ADR's that were removed or renamed
+	err := removeStalePages(adrDir, ".md", pages)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := writeSearch(site.Path, dir, site.ADRs)
+	if err != nil {
+		return nil, err
+	}
+	written = append(written, files...)
+
+	indexPage := ""
+	if site.ADRIndex != nil {
+		indexPage = site.ADRIndex.IndexFileName
+	}
+
+	files, err = writeFeeds(site.Feed, site.Path, site.Title, indexPage, site.ADRs)
+	if err != nil {
+		return nil, err
+	}
+	written = append(written, files...)
+
+	if site.ADRIndex != nil {
+		tmpl, err := pageTemplate(dir, "index.tmpl")
+		if err != nil {
+			return nil, err
+		}
+
+		file := filepath.Join(site.Path, site.Index)
+		err = updateIndexFile(file, tmpl, &SiteIndex{
+			Site:  site,
+			Title: site.Title,
+			Index: site.ADRIndex,
+		}, force)
+		if err != nil {
+			return nil, err
+		}
+		written = append(written, file)
+	}
+
+	return written, nil
+}
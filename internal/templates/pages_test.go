@@ -0,0 +1,212 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/rex/internal/adr"
+)
+
+func TestNewSite(t *testing.T) {
+	site := NewSite()
+	assert.Equal(t, DefaultPagesPath, site.Path, "")
+	assert.Equal(t, DefaultPagesTitle, site.Title, "")
+	assert.Equal(t, "adr.html", site.ADRLayout, "")
+
+	viper.Set("pages.path", "site/")
+	viper.Set("pages.title", "Decisions")
+	defer viper.Set("pages.path", "")
+	defer viper.Set("pages.title", "")
+
+	site = NewSite()
+	assert.Equal(t, "site/", site.Path, "")
+	assert.Equal(t, "Decisions", site.Title, "")
+}
+
+// testSite returns a site written to path with two ADR's.
+func testSite(path string) *Site {
+	adrs := []*adr.ADR{
+		{
+			ID:   1,
+			File: "docs/adr/1-use-go.md",
+			Body: "# Use Go\n\nWe use Go.\n",
+			Content: adr.Content{
+				Title:  "Use Go",
+				Status: "Accepted",
+				Author: "Jane Doe",
+				Date:   "2025-01-05",
+				Tags:   []string{"lang"},
+			},
+		},
+		{
+			ID:      2,
+			File:    "docs/adr/2-use postgres.md",
+			Body:    "# Use Postgres\n",
+			Content: adr.Content{Title: "Use Postgres", Status: "Draft"},
+		},
+	}
+
+	return &Site{
+		Path:          path,
+		Title:         "Decisions",
+		Index:         "index.md",
+		Config:        "_config.yml",
+		ADRLayout:     "adr.html",
+		DefaultLayout: "base.html",
+		ADRs:          adrs,
+		ADRIndex: &adr.Index{
+			DocPath: "docs/adr/",
+			Content: adr.IndexContent{
+				Adrs: []*adr.IndexAdr{
+					{Id: 1, Title: "Use Go", File: "1-use-go.md", Status: "Accepted", Date: "2025-01-05", Author: "Jane Doe", Tags: []string{"lang"}},
+					{Id: 2, Title: "Use Postgres", File: "2-use postgres.md", Status: "Draft"},
+				},
+			},
+		},
+	}
+}
+
+func TestGeneratePages(t *testing.T) {
+	path := "tests/pages/"
+	defer os.RemoveAll(path)
+
+	site := testSite(path)
+	files, err := (&EmbeddedTemplate{}).GeneratePages(site, false)
+	assert.Nil(t, err, "")
+	assert.Equal(t, []string{
+		path + "_config.yml",
+		path + "_layouts/base.html",
+		path + "_layouts/adr.html",
+		path + "adr/1-use-go.md",
+		path + "adr/2-use postgres.md",
+		path + "search.js",
+		path + "search.json",
+		path + "index.md",
+	}, files, "")
+
+	read := func(file string) string {
+		b, err := os.ReadFile(filepath.Join(path, file))
+		assert.Nil(t, err, "")
+		return string(b)
+	}
+
+	assert.Equal(
+		t,
+		"---\nlayout: adr\ntitle: 'ADR 1: Use Go'\nadr: 1\nstatus: Accepted\nauthor: Jane Doe\ndate: \"2025-01-05\"\ntags:\n  - lang\n---\n\n# Use Go\n\nWe use Go.\n\n",
+		read("adr/1-use-go.md"),
+		"",
+	)
+	assert.Equal(
+		t,
+		"---\nlayout: base\ntitle: Decisions\n---\n\n# Decisions\n\n<!-- rex:index:start -->\n\n| ID | Title | Status | Date | Author | Tags |\n| -- | ----- | ------ | ---- | ------ | ---- |\n| 1 | [Use Go](adr/1-use-go.html) | Accepted | 2025-01-05 | Jane Doe | lang |\n| 2 | [Use Postgres](adr/2-use%20postgres.html) | Draft |  |  |  |\n<!-- rex:index:end -->\n",
+		read("index.md"),
+		"",
+	)
+	assert.Contains(t, read("_config.yml"), "title: \"Decisions\"\n", "")
+	assert.Contains(t, read("_config.yml"), "layout: \"adr\"\n", "")
+	assert.Contains(t, read("_layouts/adr.html"), "---\nlayout: base\n---\n", "")
+	assert.Contains(t, read("_layouts/adr.html"), "{{ content }}", "")
+
+	// the config, layouts and content outside the index region are kept
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "_config.yml"), []byte("title: mine\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "index.md"), []byte("# Mine\n<!-- rex:index:start -->\n<!-- rex:index:end -->\n"), 0o644))
+
+	site.ADRIndex.Content.Groups = []*adr.IndexGroup{
+		{Name: "Accepted", Adrs: site.ADRIndex.Content.Adrs[:1]},
+		{Name: "Draft", Adrs: site.ADRIndex.Content.Adrs[1:]},
+	}
+	files, err = (&EmbeddedTemplate{}).GeneratePages(site, false)
+	assert.Nil(t, err, "")
+	assert.NotContains(t, files, path+"_config.yml", "")
+	assert.Equal(t, "title: mine\n", read("_config.yml"), "")
+	assert.Equal(
+		t,
+		"# Mine\n<!-- rex:index:start -->\n\n## Accepted\n\n| ID | Title | Status | Date | Author | Tags |\n| -- | ----- | ------ | ---- | ------ | ---- |\n| 1 | [Use Go](adr/1-use-go.html) | Accepted | 2025-01-05 | Jane Doe | lang |\n\n## Draft\n\n| ID | Title | Status | Date | Author | Tags |\n| -- | ----- | ------ | ---- | ------ | ---- |\n| 2 | [Use Postgres](adr/2-use%20postgres.html) | Draft |  |  |  |\n<!-- rex:index:end -->\n",
+		read("index.md"),
+		"",
+	)
+
+	_, err = (&EmbeddedTemplate{}).GeneratePages(site, true)
+	assert.Nil(t, err, "")
+	assert.Contains(t, read("_config.yml"), "title: \"Decisions\"\n", "")
+}
+
+func TestGeneratePagesStale(t *testing.T) {
+	path := "tests/pages-stale/"
+	defer os.RemoveAll(path)
+
+	// pages of ADR's that don't exist anymore are removed, other files in
+	// the ADR directory are kept
+	assert.NoError(t, os.MkdirAll(path+"adr", 0o755))
+	assert.NoError(t, os.WriteFile(path+"adr/3-removed.md", []byte("old"), 0o644))
+	assert.NoError(t, os.WriteFile(path+"adr/diagram.png", []byte("png"), 0o644))
+
+	site := testSite(path)
+	_, err := (&EmbeddedTemplate{}).GeneratePages(site, false)
+	assert.Nil(t, err, "")
+	assert.NoFileExists(t, path+"adr/3-removed.md", "")
+	assert.FileExists(t, path+"adr/diagram.png", "")
+	assert.FileExists(t, path+"adr/2-use postgres.md", "")
+
+	// the page of a renamed ADR is replaced
+	site.ADRs[1].File = "docs/adr/2-use-postgres.md"
+	_, err = (&EmbeddedTemplate{}).GeneratePages(site, false)
+	assert.Nil(t, err, "")
+	assert.NoFileExists(t, path+"adr/2-use postgres.md", "")
+	assert.FileExists(t, path+"adr/2-use-postgres.md", "")
+	assert.FileExists(t, path+"adr/1-use-go.md", "")
+}
+
+func TestGeneratePagesADRPath(t *testing.T) {
+	site := testSite("docs/")
+	_, err := (&EmbeddedTemplate{}).GeneratePages(site, false)
+	assert.EqualError(t, err, "pages can't be written to docs/, it is the ADR path, set pages.path to another directory")
+}
+
+func TestRexTemplateGeneratePages(t *testing.T) {
+	path := "tests/rex-pages/"
+	templatePath := "tests/rex-pages-templates/"
+	defer os.RemoveAll(path)
+	defer os.RemoveAll(templatePath)
+
+	assert.NoError(t, os.MkdirAll(templatePath+"gh", 0o755))
+	assert.NoError(t, os.WriteFile(templatePath+"gh/adr.tmpl", []byte("{{ .WebTitle }} {{ upper .ADR.Content.Status }}\n"), 0o644))
+
+	rt := &RexTemplate{Settings: Settings{TemplatePath: templatePath}}
+	_, err := rt.GeneratePages(testSite(path), false)
+	assert.Nil(t, err, "")
+
+	b, err := os.ReadFile(path + "adr/1-use-go.md")
+	assert.Nil(t, err, "")
+	assert.Equal(t, "ADR 1: Use Go ACCEPTED\n", string(b), "")
+
+	// templates not in the gh directory are the embedded ones
+	b, err = os.ReadFile(path + "index.md")
+	assert.Nil(t, err, "")
+	assert.Contains(t, string(b), "[Use Go](adr/1-use-go.html)", "")
+}
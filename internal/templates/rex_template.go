@@ -23,10 +23,8 @@ package templates
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
-	"strings"
 	"text/template"
 
 	"github.com/spf13/viper"
@@ -61,78 +59,71 @@ func (rt *RexTemplate) Execute() {}
 
 // CreateADR creates an ADR on disk using a template provided from the templates configuration
 // in .rex.yaml
-func (rt *RexTemplate) CreateADR(adr *adr.ADR) error {
-	// parse template from settings
-	tmpl, err := template.ParseFiles(
-		fmt.Sprintf("%s%s", rt.Settings.TemplatePath, rt.Settings.AdrTemplate),
-	)
+//
+// Returns the path of the file written.
+//
+// force: if an ADR file with the same name exists, this option will
+// overwrite it.
+func (rt *RexTemplate) CreateADR(adr *adr.ADR, force bool) (string, error) {
+	// parse the template named by the ADR, or the default template from
+	// settings
+	tmpl, err := rt.adrTemplate(adr.Content.Template)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// strip adr title to create a file name to use
-	strippedTitle := strings.Join(
-		strings.Split(strings.Trim(adr.Content.Title, "\n \t"), " "),
-		"-",
-	)
-	fileName := fmt.Sprintf("%d-%s.md", adr.ID, strippedTitle)
+	// create a file name from the ADR id and title
+	fileName, err := adr.Config.FileName(adr.ID, adr.Content.Title)
+	if err != nil {
+		return "", err
+	}
 
-	// create file on disk
-	var f *os.File
+	// check the ADR doesn't exist
 	cleanFile := filepath.Clean(
 		fmt.Sprintf("%s%s", viper.GetString("adr.path"), fileName),
 	)
-	f, err = os.Create(cleanFile)
-	if err != nil {
-		log.Fatal(err)
+	if !force && fileExists(cleanFile) {
+		return "", fmt.Errorf(
+			"ADR file found at %s, to overwrite please pass --force flag",
+			cleanFile,
+		)
 	}
 
-	// execute template with adr
-	err = tmpl.Execute(f, adr)
+	// write file to disk with adr
+	err = writeTemplate(cleanFile, tmpl, adr)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
+	return cleanFile, nil
+}
 
-	err = f.Close()
-	if err != nil {
-		log.Fatal(err)
+// adrTemplate parses the ADR template called name, an empty name or
+// DefaultTemplateName is the default template from settings.
+func (rt *RexTemplate) adrTemplate(name string) (*template.Template, error) {
+	if name != "" && name != DefaultTemplateName {
+		return namedTemplate(&rt.Settings, name)
 	}
-	return nil
+
+	return parseFiles(
+		fmt.Sprintf("%s%s", rt.Settings.TemplatePath, rt.Settings.AdrTemplate),
+	)
 }
 
-// GenerateIndex creates the index of adrs using the embedded index template
+// GenerateIndex creates the index of adrs using the configured index template
+//
+// If the index already exists and has a managed region, between
+// IndexStartMarker and IndexEndMarker, only the region is updated.
 //
-// force: if an index already exists, this option will overwrite it.
+// force: if an index without a managed region already exists, this option
+// will overwrite it.
 func (rt *RexTemplate) GenerateIndex(idx *adr.Index, force bool) error {
-	if force {
-		err := rt.writeIndex(idx)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	// check to see if index already exists
-	if fileExists(idx.DocPath + idx.IndexFileName) {
-		return fmt.Errorf(
-			"index file found at %s, to overwrite please pass --force flag",
-			idx.DocPath+idx.IndexFileName,
-		)
-	}
-
-	err := rt.writeIndex(idx)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return rt.writeIndex(idx, force)
 }
 
 // writeIndex writes the index to disk using the default embedded template
-func (rt *RexTemplate) writeIndex(idx *adr.Index) error {
+func (rt *RexTemplate) writeIndex(idx *adr.Index, force bool) error {
 	// parse template from Settings
-	tmpl, err := template.ParseFiles(
+	tmpl, err := parseFiles(
 		fmt.Sprintf(
 			"%s%s",
 			rt.Settings.TemplatePath,
@@ -143,22 +134,22 @@ func (rt *RexTemplate) writeIndex(idx *adr.Index) error {
 		return err
 	}
 
-	// create file on disk
-	var f *os.File
-	f, err = os.Create(idx.DocPath + idx.IndexFileName)
-	if err != nil {
-		log.Fatal(err)
-	}
+	// write file to disk with index template
+	return updateIndexFile(idx.DocPath+idx.IndexFileName, tmpl, idx, force)
+}
 
-	// execute index with index template
-	err = tmpl.Execute(f, idx)
-	if err != nil {
-		log.Fatal(err)
-	}
+// GeneratePages writes the GitHub Pages site and returns the files written.
+// Templates in a "gh" directory in the templates path are used over the
+// embedded ones.
+//
+// force: overwrite the Jekyll config and layouts if they already exist.
+func (rt *RexTemplate) GeneratePages(site *Site, force bool) ([]string, error) {
+	return generatePages(site, filepath.Join(rt.Settings.TemplatePath, "gh"), force)
+}
 
-	err = f.Close()
-	if err != nil {
-		log.Fatal(err)
-	}
-	return nil
+// BuildSite writes the static HTML site and returns the files written.
+// Templates in a "site" directory in the templates path are used over the
+// embedded ones.
+func (rt *RexTemplate) BuildSite(site *StaticSite) ([]string, error) {
+	return buildStaticSite(site, filepath.Join(rt.Settings.TemplatePath, "site"))
 }
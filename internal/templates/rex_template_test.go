@@ -114,19 +114,21 @@ func TestRexTemplate_CreateADR(t *testing.T) {
 		file    string
 		content string
 		adr     *adr.ADR
+		force   bool
 		err     bool
 	}{
 		"adr": {
-			file: "3-Test-3.md",
+			file: "3-test-3.md",
 			content: parseContentWithDate(
 				"# Test 3\n\n| Status | Author         |  Created | Last Update | Current Version |\n| ------ | -------------- | -------- | ----------- | --------------- |\n| Draft | Author | %s | N/A | v0.0.1 |\n\n## Context and Problem Statement\n\n## Decision Drivers\n\n## Considered Options\n\n## Decision Outcome",
 			),
 			adr: &adr.ADR{
 				Content: adr.Content{
-					Title:  "Test 3",
-					Author: "Author",
-					Status: "Draft",
-					Date:   d,
+					Title:   "Test 3",
+					Author:  "Author",
+					Status:  "Draft",
+					Date:    d,
+					Version: "v0.0.1",
 				},
 				ID: 3,
 				Config: adr.ADRConfig{
@@ -135,7 +137,9 @@ func TestRexTemplate_CreateADR(t *testing.T) {
 					AddToIndex: true,
 				},
 			},
-			err: false,
+			// overwrites the ADR written by the embedded template
+			force: true,
+			err:   false,
 		},
 	}
 
@@ -145,7 +149,7 @@ func TestRexTemplate_CreateADR(t *testing.T) {
 			viper.Set("templates.path", defaultTemplatesPath)
 
 			tmp := NewTemplate()
-			err := tmp.CreateADR(test.adr)
+			_, err := tmp.CreateADR(test.adr, test.force)
 			if err != nil {
 				t.Errorf(
 					"error creating test file: %v, err: %v",
@@ -184,8 +188,8 @@ func TestRexTemplate_GenerateIndex(t *testing.T) {
 		err     bool
 	}{
 		"create": {
-			file:    defaultTemplatesAdrIndex,
-			content: "# ADR Index\n\n## ADRs\n\n| ID | Title | Link |\n| -- | ----- | ---- |\n| 1 | test1 | link |\n| 2 | test2 | link |\n| 3 | Test-3 | link |\n",
+			file:    "rex_" + defaultAdrIndexPage,
+			content: "# ADR Index\n\n## ADRs\n\n| ID | Title | Link |\n| -- | ----- | ---- |\n| 1 | test1 | link |\n| 2 | test2 | link |\n| 3 | Test-3 | link |",
 			idx: &adr.Index{
 				DocPath:       defaultAdrPath,
 				IndexFileName: "rex_" + defaultAdrIndexPage,
@@ -0,0 +1,156 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/fileutil"
+	"github.com/donaldgifford/rex/internal/markdown"
+)
+
+// Files of the search written with the GitHub Pages and static sites.
+// searchScript in a site's templates directory overrides the embedded
+// script.
+const (
+	searchIndex  = "search.json"
+	searchScript = "search.js"
+)
+
+// summaryLength is the most characters of an ADR shown as its summary.
+const summaryLength = 200
+
+// stopWords are left out of the search tokens, they match almost every
+// ADR.
+var stopWords = map[string]bool{
+	"an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "from": true,
+	"has": true, "have": true, "in": true, "is": true, "it": true,
+	"its": true, "of": true, "on": true, "or": true, "so": true,
+	"that": true, "the": true, "this": true, "to": true, "was": true,
+	"we": true, "were": true, "which": true, "will": true, "with": true,
+}
+
+// SearchEntry is an ADR in the search index of a site. URL is the path of
+// the ADR's page from the root of the site and Tokens the distinct words
+// of its body, lower cased.
+type SearchEntry struct {
+	ID      int      `json:"id"`
+	Title   string   `json:"title"`
+	Status  string   `json:"status,omitempty"`
+	Author  string   `json:"author,omitempty"`
+	Date    string   `json:"date,omitempty"`
+	Tags    []string `json:"tags"`
+	URL     string   `json:"url"`
+	Summary string   `json:"summary,omitempty"`
+	Tokens  []string `json:"tokens"`
+}
+
+// newSearchIndex returns the search entries of adrs.
+func newSearchIndex(adrs []*adr.ADR) []*SearchEntry {
+	entries := make([]*SearchEntry, 0, len(adrs))
+	for _, a := range adrs {
+		prose := a.Prose()
+		tags := a.Content.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+
+		entries = append(entries, &SearchEntry{
+			ID:      a.ID,
+			Title:   a.Content.Title,
+			Status:  a.Content.Status,
+			Author:  a.Content.Author,
+			Date:    a.Content.Date,
+			Tags:    tags,
+			URL:     link(staticPageURL(a.File)),
+			Summary: markdown.Summary(prose, summaryLength),
+			Tokens:  searchTokens(markdown.Text(prose)),
+		})
+	}
+	return entries
+}
+
+// searchTokens returns the distinct words in text, lower cased, in the
+// order they are first used. Single characters and stop words are left
+// out.
+func searchTokens(text string) []string {
+	seen := map[string]bool{}
+	tokens := []string{}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if len([]rune(w)) < 2 || stopWords[w] || seen[w] {
+			continue
+		}
+		seen[w] = true
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// writeSearch writes the search index of adrs and the search script from
+// dir, see searchScriptFile, to the root of a site at sitePath and returns
+// the files written.
+func writeSearch(sitePath, dir string, adrs []*adr.ADR) ([]string, error) {
+	script, err := searchScriptFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	scriptFile := filepath.Join(sitePath, searchScript)
+	err = fileutil.WriteFile(scriptFile, func(w io.Writer) error {
+		_, err := w.Write(script)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	indexFile := filepath.Join(sitePath, searchIndex)
+	err = fileutil.WriteFile(indexFile, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(newSearchIndex(adrs))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{scriptFile, indexFile}, nil
+}
+
+// searchScriptFile reads the search script from dir, or the embedded
+// script if dir is empty or doesn't have it.
+func searchScriptFile(dir string) ([]byte, error) {
+	if dir != "" && fileExists(filepath.Join(dir, searchScript)) {
+		return os.ReadFile(filepath.Join(dir, searchScript))
+	}
+	return DefaultRexTemplates.ReadFile(path.Join("default", searchScript))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/rex/internal/adr"
+)
+
+func TestSearchTokens(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		expected []string
+	}{
+		"empty": {
+			text:     "",
+			expected: []string{},
+		},
+		"words": {
+			text:     "We use Postgres for the cache.\nPostgres is a DB, v2 (beta)",
+			expected: []string{"use", "postgres", "cache", "db", "v2", "beta"},
+		},
+		"unicode": {
+			text:     "Café naïve-approach",
+			expected: []string{"café", "naïve", "approach"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, searchTokens(test.text), "")
+		})
+	}
+}
+
+func TestNewSearchIndex(t *testing.T) {
+	adrs := []*adr.ADR{
+		{
+			ID:   1,
+			File: "docs/adr/1-use go.md",
+			Body: "# Use Go\n\n| Status | Author |\n| --- | --- |\n| Accepted | Jane |\n\n## Context\n\nWe need a **language** for the [CLI](https://example.com).\n",
+			Content: adr.Content{
+				Title:  "Use Go",
+				Status: "Accepted",
+				Author: "Jane Doe",
+				Date:   "2025-01-05",
+				Tags:   []string{"lang"},
+			},
+		},
+		{
+			ID:      2,
+			File:    "docs/adr/2-cache.md",
+			Content: adr.Content{Title: "Cache"},
+		},
+	}
+
+	assert.Equal(t, []*SearchEntry{
+		{
+			ID:      1,
+			Title:   "Use Go",
+			Status:  "Accepted",
+			Author:  "Jane Doe",
+			Date:    "2025-01-05",
+			Tags:    []string{"lang"},
+			URL:     "adr/1-use%20go.html",
+			Summary: "We need a language for the CLI.",
+			Tokens:  []string{"context", "need", "language", "cli"},
+		},
+		{
+			ID:     2,
+			Title:  "Cache",
+			Tags:   []string{},
+			URL:    "adr/2-cache.html",
+			Tokens: []string{},
+		},
+	}, newSearchIndex(adrs), "")
+}
+
+func TestWriteSearch(t *testing.T) {
+	path := "tests/search/"
+	templatePath := "tests/search-templates/"
+	defer os.RemoveAll(path)
+	defer os.RemoveAll(templatePath)
+
+	assert.NoError(t, os.MkdirAll(path, 0o755))
+	adrs := []*adr.ADR{{ID: 1, File: "1-use-go.md", Content: adr.Content{Title: "Use Go"}}}
+
+	files, err := writeSearch(path, "", adrs)
+	assert.Nil(t, err, "")
+	assert.Equal(t, []string{path + "search.js", path + "search.json"}, files, "")
+
+	script, err := os.ReadFile(path + "search.js")
+	assert.Nil(t, err, "")
+	embedded, err := DefaultRexTemplates.ReadFile("default/search.js")
+	assert.Nil(t, err, "")
+	assert.Equal(t, string(embedded), string(script), "")
+
+	b, err := os.ReadFile(path + "search.json")
+	assert.Nil(t, err, "")
+	var entries []*SearchEntry
+	assert.Nil(t, json.Unmarshal(b, &entries), "")
+	assert.Len(t, entries, 1, "")
+	assert.Equal(t, "adr/1-use-go.html", entries[0].URL, "")
+
+	// a search script in the templates directory is used over the embedded one
+	assert.NoError(t, os.MkdirAll(templatePath, 0o755))
+	assert.NoError(t, os.WriteFile(templatePath+"search.js", []byte("// custom\n"), 0o644))
+
+	_, err = writeSearch(path, templatePath, adrs)
+	assert.Nil(t, err, "")
+	script, err = os.ReadFile(path + "search.js")
+	assert.Nil(t, err, "")
+	assert.Equal(t, "// custom\n", string(script), "")
+}
@@ -0,0 +1,342 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"cmp"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/donaldgifford/rex/internal/adr"
+	"github.com/donaldgifford/rex/internal/fileutil"
+	"github.com/donaldgifford/rex/internal/markdown"
+)
+
+// DefaultStaticPath is the directory the static site is written to when
+// "site.path" isn't set.
+const DefaultStaticPath = "public/"
+
+// staticTemplatePath is the embedded directory of the static site
+// templates, a "site" directory in the templates path overrides them.
+const staticTemplatePath = "default/site"
+
+// StaticSite is a standalone HTML site with a page for every ADR and an
+// index page listing them, which can be hosted on any static file server.
+// It is configured under "site" in .rex.yaml.
+//
+// Feed is written with the site when it is enabled. ADRs and ADRIndex
+// hold the ADR's the pages are generated from, ADRs ordered by id.
+type StaticSite struct {
+	Path     string
+	Title    string
+	Feed     *Feed
+	ADRs     []*adr.ADR
+	ADRIndex *adr.Index
+}
+
+// NewStaticSite reads the site settings under "site". The title defaults
+// to the GitHub Pages title.
+func NewStaticSite() *StaticSite {
+	return &StaticSite{
+		Path: cmp.Or(viper.GetString("site.path"), DefaultStaticPath),
+		Title: cmp.Or(
+			viper.GetString("site.title"),
+			viper.GetString("pages.title"),
+			DefaultPagesTitle,
+		),
+		Feed: NewFeed(),
+	}
+}
+
+// StaticPage is the data the static site templates are executed with.
+//
+// Title is empty for the index page. Root is the path from the page to the
+// root of the site and Nav links every ADR for the sidebar. ADR, Body,
+// Relations, Prev and Next are only set for ADR pages and Groups only for
+// the index page.
+type StaticPage struct {
+	Site      *StaticSite
+	Title     string
+	Root      string
+	Nav       []*StaticLink
+	ADR       *adr.ADR
+	Body      htmltemplate.HTML
+	Relations []*StaticRelation
+	Prev      *StaticLink
+	Next      *StaticLink
+	Groups    []*SiteGroup
+}
+
+// FeedURL returns the path of the Atom feed from the page, or an empty
+// string if the site has no feed.
+func (p *StaticPage) FeedURL() string {
+	if !p.Site.Feed.Enabled() {
+		return ""
+	}
+	return p.Root + atomFeed
+}
+
+// StaticLink links to the page of an ADR. Current is set on the link to
+// the page it is shown on.
+type StaticLink struct {
+	ID      int
+	Title   string
+	Status  string
+	URL     string
+	Current bool
+}
+
+// StaticRelation links to the page of a related ADR. URL is empty if the
+// related ADR doesn't exist.
+type StaticRelation struct {
+	StaticLink
+	Label string
+}
+
+// staticPageURL returns the path of the page of the ADR in file from the
+// root of the site.
+func staticPageURL(file string) string {
+	base := filepath.Base(file)
+	return path.Join(siteADRDir, strings.TrimSuffix(base, filepath.Ext(base))+".html")
+}
+
+// staticLinks returns a function rewriting links in an ADR to other
+// markdown files to the pages generated from them. Links to the ADR index
+// page go to the site index page.
+func staticLinks(indexPage string) func(string) string {
+	return func(dest string) string {
+		u, err := url.Parse(dest)
+		if err != nil || u.Scheme != "" || u.Host != "" ||
+			path.IsAbs(u.Path) || path.Ext(u.Path) != ".md" {
+			return dest
+		}
+
+		if u.Path == indexPage {
+			u.Path = "../index.html"
+		} else {
+			u.Path = strings.TrimSuffix(u.Path, ".md") + ".html"
+		}
+		return u.String()
+	}
+}
+
+// staticTemplate parses the layout and the page template name from dir,
+// falling back to the embedded templates if dir is empty or doesn't have
+// them.
+func staticTemplate(dir, name string) (*htmltemplate.Template, error) {
+	layout, err := staticFile(dir, "layout.html")
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := staticFile(dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := htmltemplate.New("layout.html").
+		Funcs(htmltemplate.FuncMap(FuncMap())).
+		Parse(string(layout))
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.New(name).Parse(string(page))
+}
+
+// staticFile reads the static site file name from dir, or the embedded
+// file if dir is empty or doesn't have it.
+func staticFile(dir, name string) ([]byte, error) {
+	if dir != "" && fileExists(filepath.Join(dir, name)) {
+		return os.ReadFile(filepath.Join(dir, name))
+	}
+	return DefaultRexTemplates.ReadFile(path.Join(staticTemplatePath, name))
+}
+
+// buildStaticSite writes the site with the templates in dir, see
+// staticTemplate, its search index and feeds and returns the files
+// written. ADR pages left from ADR's that no longer exist are removed.
+func buildStaticSite(site *StaticSite, dir string) ([]string, error) {
+	adrDir := filepath.Join(site.Path, siteADRDir)
+	if site.ADRIndex != nil &&
+		filepath.Clean(adrDir) == filepath.Clean(site.ADRIndex.DocPath) {
+		return nil, fmt.Errorf(
+			"the site can't be written to %s, it is the ADR path, set site.path to another directory",
+			site.Path,
+		)
+	}
+
+	err := os.MkdirAll(adrDir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	write := func(file string, tmpl *htmltemplate.Template, page *StaticPage) error {
+		err := fileutil.WriteFile(file, func(w io.Writer) error {
+			return tmpl.ExecuteTemplate(w, "layout.html", page)
+		})
+		if err != nil {
+			return err
+		}
+		written = append(written, file)
+		return nil
+	}
+
+	css, err := staticFile(dir, "style.css")
+	if err != nil {
+		return nil, err
+	}
+	file := filepath.Join(site.Path, "style.css")
+	err = fileutil.WriteFile(file, func(w io.Writer) error {
+		_, err := w.Write(css)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	written = append(written, file)
+
+	files, err := writeSearch(site.Path, dir, site.ADRs)
+	if err != nil {
+		return nil, err
+	}
+	written = append(written, files...)
+
+	indexPage := ""
+	if site.ADRIndex != nil {
+		indexPage = site.ADRIndex.IndexFileName
+	}
+
+	files, err = writeFeeds(site.Feed, site.Path, site.Title, indexPage, site.ADRs)
+	if err != nil {
+		return nil, err
+	}
+	written = append(written, files...)
+
+	if site.ADRIndex != nil {
+		tmpl, err := staticTemplate(dir, "index.html")
+		if err != nil {
+			return nil, err
+		}
+
+		err = write(filepath.Join(site.Path, "index.html"), tmpl, &StaticPage{
+			Site:   site,
+			Nav:    site.nav("", nil),
+			Groups: (&SiteIndex{Index: site.ADRIndex}).Groups(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tmpl, err := staticTemplate(dir, "adr.html")
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := &markdown.HTMLRenderer{Link: staticLinks(indexPage)}
+
+	// the ADR's are written by the project, so their HTML is trusted
+	pages := map[string]bool{}
+	for i, a := range site.ADRs {
+		page := &StaticPage{
+			Site:      site,
+			Title:     fmt.Sprintf("ADR %d: %s", a.ID, a.Content.Title),
+			Root:      "../",
+			Nav:       site.nav("../", a),
+			ADR:       a,
+			Body:      htmltemplate.HTML(renderer.Render(a.ProseWithoutRelations())),
+			Relations: site.relations("../", a),
+		}
+		if i > 0 {
+			page.Prev = site.link("../", site.ADRs[i-1])
+		}
+		if i < len(site.ADRs)-1 {
+			page.Next = site.link("../", site.ADRs[i+1])
+		}
+
+		file := filepath.Join(site.Path, staticPageURL(a.File))
+		err := write(file, tmpl, page)
+		if err != nil {
+			return nil, err
+		}
+		pages[filepath.Base(file)] = true
+	}
+
+	// remove the pages of ADR's that were removed or renamed
+	err = removeStalePages(adrDir, ".html", pages)
+	if err != nil {
+		return nil, err
+	}
+
+	return written, nil
+}
+
+// link returns the link to the page of a from a page at root.
+func (s *StaticSite) link(root string, a *adr.ADR) *StaticLink {
+	return &StaticLink{
+		ID:     a.ID,
+		Title:  a.Content.Title,
+		Status: a.Content.Status,
+		URL:    root + staticPageURL(a.File),
+	}
+}
+
+// nav returns the links to every ADR from a page at root, marking the link
+// to current.
+func (s *StaticSite) nav(root string, current *adr.ADR) []*StaticLink {
+	links := make([]*StaticLink, 0, len(s.ADRs))
+	for _, a := range s.ADRs {
+		l := s.link(root, a)
+		l.Current = a == current
+		links = append(links, l)
+	}
+	return links
+}
+
+// relations returns the links to the ADR's related to a from a page at
+// root.
+func (s *StaticSite) relations(root string, a *adr.ADR) []*StaticRelation {
+	relations := make([]*StaticRelation, 0, len(a.Content.Relations))
+	for _, r := range a.Content.Relations {
+		rel := &StaticRelation{
+			StaticLink: StaticLink{ID: r.ID, Title: r.Title},
+			Label:      r.Label(),
+		}
+		for _, other := range s.ADRs {
+			if other.ID == r.ID {
+				rel.StaticLink = *s.link(root, other)
+				break
+			}
+		}
+		relations = append(relations, rel)
+	}
+	return relations
+}
@@ -0,0 +1,251 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/rex/internal/adr"
+)
+
+func TestNewStaticSite(t *testing.T) {
+	site := NewStaticSite()
+	assert.Equal(t, DefaultStaticPath, site.Path, "")
+	assert.Equal(t, DefaultPagesTitle, site.Title, "")
+
+	viper.Set("pages.title", "Pages")
+	defer viper.Set("pages.title", "")
+	assert.Equal(t, "Pages", NewStaticSite().Title, "")
+
+	viper.Set("site.path", "out/")
+	viper.Set("site.title", "Decisions")
+	defer viper.Set("site.path", "")
+	defer viper.Set("site.title", "")
+
+	site = NewStaticSite()
+	assert.Equal(t, "out/", site.Path, "")
+	assert.Equal(t, "Decisions", site.Title, "")
+}
+
+func TestStaticLinks(t *testing.T) {
+	tests := map[string]struct {
+		dest     string
+		expected string
+	}{
+		"adr":      {dest: "2-use-go.md", expected: "2-use-go.html"},
+		"fragment": {dest: "2-use-go.md#decision-outcome", expected: "2-use-go.html#decision-outcome"},
+		"index":    {dest: "README.md", expected: "../index.html"},
+		"url":      {dest: "https://example.com/README.md", expected: "https://example.com/README.md"},
+		"absolute": {dest: "/docs/guide.md", expected: "/docs/guide.md"},
+		"other":    {dest: "diagram.png", expected: "diagram.png"},
+		"anchor":   {dest: "#context", expected: "#context"},
+	}
+
+	link := staticLinks("README.md")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, link(test.dest), "")
+		})
+	}
+}
+
+// testStaticSite returns a static site written to path with three related
+// ADR's.
+func testStaticSite(path string) *StaticSite {
+	adrs := []*adr.ADR{
+		{
+			ID:   1,
+			File: "docs/adr/1-use-mysql.md",
+			Body: "# Use MySQL\n\n| Status | Author |\n| --- | --- |\n| Superseded | Jane |\n\nSee [Postgres](2-use-postgres.md) & the [index](README.md).\n",
+			Content: adr.Content{
+				Title:     "Use MySQL",
+				Status:    "Superseded",
+				Relations: []adr.Relation{{Type: adr.SupersededBy, ID: 2, Title: "Use Postgres"}},
+			},
+		},
+		{
+			ID:   2,
+			File: "docs/adr/2-use-postgres.md",
+			Body: "# Use Postgres\n\nWe use **Postgres**.\n\n## Related ADRs\n\n- Supersedes [ADR 1: Use MySQL](1-use-mysql.md)\n",
+			Content: adr.Content{
+				Title:     "Use Postgres",
+				Status:    "Accepted",
+				Author:    "Jane Doe",
+				Tags:      []string{"db", "storage"},
+				Fields:    map[string]any{"jira": "PLAT-1"},
+				Relations: []adr.Relation{{Type: adr.Supersedes, ID: 1}, {Type: adr.RelatesTo, ID: 9}},
+			},
+		},
+		{
+			ID:      3,
+			File:    "docs/adr/3-cache.md",
+			Content: adr.Content{Title: "Cache <sessions>", Status: "Draft"},
+		},
+	}
+
+	var links []*adr.IndexAdr
+	for _, a := range adrs {
+		links = append(links, &adr.IndexAdr{
+			Id:     a.ID,
+			Title:  a.Content.Title,
+			File:   filepath.Base(a.File),
+			Status: a.Content.Status,
+		})
+	}
+
+	return &StaticSite{
+		Path:  path,
+		Title: "Decisions",
+		ADRs:  adrs,
+		ADRIndex: &adr.Index{
+			DocPath:       "docs/adr/",
+			IndexFileName: "README.md",
+			Content:       adr.IndexContent{Adrs: links},
+		},
+	}
+}
+
+func TestBuildStaticSite(t *testing.T) {
+	path := "tests/static/"
+	defer os.RemoveAll(path)
+
+	// pages of ADR's that don't exist anymore are removed
+	assert.NoError(t, os.MkdirAll(path+"adr", 0o755))
+	assert.NoError(t, os.WriteFile(path+"adr/4-removed.html", []byte("old"), 0o644))
+
+	files, err := (&EmbeddedTemplate{}).BuildSite(testStaticSite(path))
+	assert.Nil(t, err, "")
+	assert.Equal(t, []string{
+		path + "style.css",
+		path + "search.js",
+		path + "search.json",
+		path + "index.html",
+		path + "adr/1-use-mysql.html",
+		path + "adr/2-use-postgres.html",
+		path + "adr/3-cache.html",
+	}, files, "")
+	assert.NoFileExists(t, path+"adr/4-removed.html", "")
+
+	read := func(file string) string {
+		b, err := os.ReadFile(filepath.Join(path, file))
+		assert.Nil(t, err, "")
+		return string(b)
+	}
+
+	index := read("index.html")
+	assert.Contains(t, index, "<title>Decisions</title>", "")
+	assert.Contains(t, index, `<link rel="stylesheet" href="style.css">`, "")
+	assert.Contains(t, index, `<input id="search-input" type="search"`, "")
+	assert.Contains(t, index, `<script src="search.js" data-root="" defer></script>`, "")
+	assert.Contains(t, index, `<td><a href="adr/2-use-postgres.html">Use Postgres</a></td>`, "")
+	assert.Contains(t, index, `<span class="badge status-accepted">Accepted</span>`, "")
+	assert.Contains(t, index, "Cache &lt;sessions&gt;", "")
+	assert.NotContains(t, index, "application/atom+xml", "")
+
+	first := read("adr/1-use-mysql.html")
+	assert.Contains(t, first, "<title>ADR 1: Use MySQL | Decisions</title>", "")
+	assert.Contains(t, first, `<link rel="stylesheet" href="../style.css">`, "")
+	assert.Contains(t, first, `<script src="../search.js" data-root="../" defer></script>`, "")
+	assert.Contains(t, first, `<li class="current">`+"\n"+`          <a href="../adr/1-use-mysql.html">`, "")
+	assert.Contains(t, first, `<li>Superseded by <a href="../adr/2-use-postgres.html">ADR 2: Use Postgres</a> <span class="badge status-accepted">Accepted</span></li>`, "")
+	assert.Contains(t, first, `<p>See <a href="2-use-postgres.html">Postgres</a> &amp; the <a href="../index.html">index</a>.</p>`, "")
+	assert.NotContains(t, first, "<h1 id=", "the title and metadata table are shown by the layout")
+	assert.NotContains(t, first, `class="prev"`, "")
+	assert.Contains(t, first, `<a class="next" href="../adr/2-use-postgres.html">ADR 2: Use Postgres &rarr;</a>`, "")
+
+	second := read("adr/2-use-postgres.html")
+	assert.Contains(t, second, "<dt>Tags</dt><dd>db, storage</dd>", "")
+	assert.Contains(t, second, "<dt>jira</dt><dd>PLAT-1</dd>", "")
+	assert.Contains(t, second, "<p>We use <strong>Postgres</strong>.</p>", "")
+	assert.Contains(t, second, `<li>Supersedes <a href="../adr/1-use-mysql.html">ADR 1: Use MySQL</a>`, "")
+	assert.Contains(t, second, "<li>Relates to ADR 9 </li>", "")
+	assert.Equal(t, 1, strings.Count(second, "Supersedes"), "relations are only listed once")
+	assert.NotContains(t, second, "Related ADRs", "")
+	assert.Contains(t, second, `<a class="prev" href="../adr/1-use-mysql.html">&larr; ADR 1: Use MySQL</a>`, "")
+	assert.Contains(t, second, `<a class="next" href="../adr/3-cache.html">ADR 3: Cache &lt;sessions&gt; &rarr;</a>`, "")
+
+	assert.NotContains(t, read("adr/3-cache.html"), `class="next"`, "")
+}
+
+func TestBuildStaticSiteADRPath(t *testing.T) {
+	_, err := (&EmbeddedTemplate{}).BuildSite(testStaticSite("docs/"))
+	assert.EqualError(t, err, "the site can't be written to docs/, it is the ADR path, set site.path to another directory")
+}
+
+func TestRexTemplateBuildSite(t *testing.T) {
+	path := "tests/rex-static/"
+	templatePath := "tests/rex-static-templates/"
+	defer os.RemoveAll(path)
+	defer os.RemoveAll(templatePath)
+
+	assert.NoError(t, os.MkdirAll(templatePath+"site", 0o755))
+	assert.NoError(t, os.WriteFile(templatePath+"site/style.css", []byte("body {}\n"), 0o644))
+	assert.NoError(t, os.WriteFile(
+		templatePath+"site/adr.html",
+		[]byte(`{{ define "content" }}<h1>{{ upper .ADR.Content.Title }}</h1>{{ end }}`),
+		0o644,
+	))
+
+	rt := &RexTemplate{Settings: Settings{TemplatePath: templatePath}}
+	_, err := rt.BuildSite(testStaticSite(path))
+	assert.Nil(t, err, "")
+
+	b, err := os.ReadFile(path + "style.css")
+	assert.Nil(t, err, "")
+	assert.Equal(t, "body {}\n", string(b), "")
+
+	// the layout not in the site directory is the embedded one
+	b, err = os.ReadFile(path + "adr/1-use-mysql.html")
+	assert.Nil(t, err, "")
+	assert.Contains(t, string(b), "<main><h1>USE MYSQL</h1>", "")
+	assert.Contains(t, string(b), `<nav class="sidebar">`, "")
+}
+
+func TestBuildStaticSiteFeed(t *testing.T) {
+	path := "tests/static-feed/"
+	defer os.RemoveAll(path)
+
+	site := testStaticSite(path)
+	for _, a := range site.ADRs {
+		a.Content.Date = "2025-01-05"
+	}
+	site.Feed = &Feed{URL: "https://example.com/adrs/", RSS: true}
+
+	files, err := (&EmbeddedTemplate{}).BuildSite(site)
+	assert.Nil(t, err, "")
+	assert.Contains(t, files, path+"feed.xml", "")
+	assert.Contains(t, files, path+"rss.xml", "")
+
+	b, err := os.ReadFile(path + "adr/2-use-postgres.html")
+	assert.Nil(t, err, "")
+	assert.Contains(t, string(b), `<link rel="alternate" type="application/atom+xml" title="Decisions" href="../feed.xml">`, "")
+
+	b, err = os.ReadFile(path + "index.html")
+	assert.Nil(t, err, "")
+	assert.Contains(t, string(b), `href="feed.xml">`, "")
+}
@@ -43,8 +43,10 @@ type Template interface {
 	Read(file string) ([]byte, error)
 	Execute() // Not implemented
 	GetSettings() *Settings
-	CreateADR(adr *adr.ADR) error
+	CreateADR(adr *adr.ADR, force bool) (string, error)
 	GenerateIndex(idx *adr.Index, force bool) error
+	GeneratePages(site *Site, force bool) ([]string, error)
+	BuildSite(site *StaticSite) ([]string, error)
 }
 
 // NewTemplate returns a template struct to use based on if in the Settings
@@ -56,6 +58,7 @@ func NewTemplate() Template {
 				TemplatePath:  viper.GetString("templates.path"),
 				AdrTemplate:   viper.GetString("templates.adr.default"),
 				IndexTemplate: viper.GetString("templates.adr.index"),
+				Named:         namedTemplates(),
 			},
 		}
 	} else {
@@ -64,16 +67,21 @@ func NewTemplate() Template {
 				TemplatePath:  "default/",
 				AdrTemplate:   "adr.tmpl",
 				IndexTemplate: "index.tmpl",
+				Named:         namedTemplates(),
 			},
 		}
 	}
 }
 
 // Settings holds template data on where to get the different templates used.
+//
+// Named holds the path of the ADR templates configured under
+// "templates.adr.named" by name.
 type Settings struct {
 	TemplatePath  string
 	AdrTemplate   string
 	IndexTemplate string
+	Named         map[string]string
 }
 
 // fileExists returns checks if a file already exists on disk
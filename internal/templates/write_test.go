@@ -0,0 +1,80 @@
+/*
+Copyright © 2024-2025 Donald Gifford <dgifford06@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package templates
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/rex/internal/adr"
+)
+
+func TestCreateADRExists(t *testing.T) {
+	existsPath := "tests/exists/"
+	err := createTestFolder(existsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(existsPath+"1-exists.md", []byte("# Exists\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("adr.path", existsPath)
+	defer viper.Set("adr.path", defaultAdrPath)
+
+	a := &adr.ADR{
+		ID:      1,
+		Content: adr.Content{Title: "Exists", Status: "Draft"},
+	}
+
+	et := &EmbeddedTemplate{
+		Settings: Settings{
+			TemplatePath:  "default/",
+			AdrTemplate:   "adr.tmpl",
+			IndexTemplate: "index.tmpl",
+		},
+	}
+
+	_, err = et.CreateADR(a, false)
+	assert.EqualError(
+		t,
+		err,
+		"ADR file found at tests/exists/1-exists.md, to overwrite please pass --force flag",
+	)
+
+	b, err := os.ReadFile(existsPath + "1-exists.md")
+	assert.Nil(t, err, "")
+	assert.Equal(t, "# Exists\n", string(b), "")
+
+	file, err := et.CreateADR(a, true)
+	assert.Nil(t, err, "")
+	assert.Equal(t, existsPath+"1-exists.md", file, "")
+
+	b, err = os.ReadFile(existsPath + "1-exists.md")
+	assert.Nil(t, err, "")
+	assert.Contains(t, string(b), "title: Exists\n", "")
+}